@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"gin-backend-app/internal/app"
+	"gin-backend-app/internal/config"
+	"gin-backend-app/internal/logging"
+	"gin-backend-app/pkg/utils"
+)
+
+const usage = `usage: server <command> [arguments]
+
+commands:
+  serve                                   start the HTTP server (default)
+  migrate up|down|status|new              manage the database schema
+  user create --name N --email E [--password-stdin] [--locale en|id] [--verified]
+  user verify --user <email|id>           mark the user's email as verified
+  user reset-password --user <email|id> [--password-stdin]
+  user delete --user <email|id> --yes     delete the user and all their data
+  tokens cleanup                          delete expired verification tokens
+  reports regenerate --user <email|id> --period weekly|monthly [--at YYYY-MM-DD]
+
+Passwords are generated and printed unless --password-stdin reads one from
+the first line of standard input.
+`
+
+// errUsage is returned after the usage text has already been printed.
+var errUsage = errors.New("invalid arguments")
+
+// runCLI dispatches os.Args[1:] to a subcommand; no arguments starts the
+// server.
+func runCLI(args []string) error {
+	if len(args) == 0 {
+		runServe()
+		return nil
+	}
+
+	// Ctrl-C cancels the queries of a running subcommand.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "serve":
+		runServe()
+		return nil
+	case "migrate":
+		return runMigrate(args)
+	case "user":
+		return runUser(ctx, args)
+	case "tokens":
+		return runTokens(ctx, args)
+	case "reports":
+		return runReports(ctx, args)
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return nil
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+// newFlagSet returns a flag set that reports errors instead of exiting.
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage of server %s:\n", name)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// loadConfig loads the configuration and sets up logging from it.
+func loadConfig() (*config.Config, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if err := logging.Setup(cfg.Log); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// openAdminApp builds the same container as the server without starting its
+// scheduler or listener.
+func openAdminApp() (*app.App, func(), error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	utils.SetJWTSecret(cfg.JWT.Secret)
+
+	db, err := config.OpenDatabase(cfg.Database)
+	if err != nil {
+		return nil, nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, nil, err
+	}
+	closeDB := func() { sqlDB.Close() }
+
+	a, err := app.New(cfg, db, app.Options{})
+	if err != nil {
+		closeDB()
+		return nil, nil, err
+	}
+	return a, closeDB, nil
+}
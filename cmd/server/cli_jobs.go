@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gin-backend-app/internal/models"
+)
+
+func runTokens(ctx context.Context, args []string) error {
+	if len(args) != 1 || args[0] != "cleanup" {
+		return fmt.Errorf("expected `tokens cleanup`\n%s", usage)
+	}
+
+	a, closeApp, err := openAdminApp()
+	if err != nil {
+		return err
+	}
+	defer closeApp()
+
+	return a.Services.EmailVerification.CleanupExpiredTokens(ctx)
+}
+
+func runReports(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "regenerate" {
+		return fmt.Errorf("expected `reports regenerate`\n%s", usage)
+	}
+
+	flags := newFlagSet("reports regenerate")
+	identifier := flags.String("user", "", "user email or id")
+	period := flags.String("period", "", "weekly or monthly")
+	at := flags.String("at", "", "a date inside the period, YYYY-MM-DD (default today)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return errUsage
+	}
+	if *identifier == "" {
+		return errors.New("--user is required")
+	}
+
+	periodType := models.PeriodType(*period)
+	if periodType != models.PeriodWeekly && periodType != models.PeriodMonthly {
+		return errors.New("--period must be weekly or monthly")
+	}
+
+	date := time.Now()
+	if *at != "" {
+		var err error
+		if date, err = time.ParseInLocation("2006-01-02", *at, time.Local); err != nil {
+			return fmt.Errorf("invalid --at date: %w", err)
+		}
+	}
+
+	a, closeApp, err := openAdminApp()
+	if err != nil {
+		return err
+	}
+	defer closeApp()
+
+	user, err := a.Services.User.FindUser(ctx, *identifier)
+	if err != nil {
+		return err
+	}
+
+	report, err := a.Services.Report.GenerateReport(ctx, user.ID, periodType, date)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("regenerated %s report %s..%s for %s: income %.2f, expense %.2f, net %.2f\n",
+		report.PeriodType,
+		report.PeriodStart.Format("2006-01-02"),
+		report.PeriodEnd.Format("2006-01-02"),
+		user.Email,
+		report.TotalIncome, report.TotalExpense, report.NetFlow)
+	return nil
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"gin-backend-app/internal/dto/request"
+)
+
+func runUser(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing user command\n%s", usage)
+	}
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "create":
+		return runUserCreate(ctx, args)
+	case "verify":
+		return runUserVerify(ctx, args)
+	case "reset-password":
+		return runUserResetPassword(ctx, args)
+	case "delete":
+		return runUserDelete(ctx, args)
+	default:
+		return fmt.Errorf("unknown user command %q\n%s", command, usage)
+	}
+}
+
+func runUserCreate(ctx context.Context, args []string) error {
+	flags := newFlagSet("user create")
+	name := flags.String("name", "", "username")
+	email := flags.String("email", "", "email address")
+	passwordStdin := flags.Bool("password-stdin", false, "read the password from stdin instead of generating one")
+	locale := flags.String("locale", "en", "email language (en or id)")
+	verified := flags.Bool("verified", false, "mark the email as verified right away")
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	if *name == "" || *email == "" {
+		return errors.New("--name and --email are required")
+	}
+
+	password, err := readPassword(*passwordStdin)
+	if err != nil {
+		return err
+	}
+
+	a, closeApp, err := openAdminApp()
+	if err != nil {
+		return err
+	}
+	defer closeApp()
+
+	req := request.CreateUserRequest{
+		Name:     *name,
+		Email:    *email,
+		Password: password,
+		Locale:   *locale,
+	}
+	create := a.Services.User.CreateUser
+	if *verified {
+		// Verified users never get the verification email.
+		create = a.Services.User.CreateVerifiedUser
+	}
+	created, err := create(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("created user %s (%s)\n", created.User.ID, created.User.Email)
+	if !*passwordStdin {
+		fmt.Printf("password: %s\n", password)
+	}
+	return nil
+}
+
+func runUserVerify(ctx context.Context, args []string) error {
+	flags := newFlagSet("user verify")
+	identifier := flags.String("user", "", "user email or id")
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	if *identifier == "" {
+		return errors.New("--user is required")
+	}
+
+	a, closeApp, err := openAdminApp()
+	if err != nil {
+		return err
+	}
+	defer closeApp()
+
+	user, err := a.Services.User.FindUser(ctx, *identifier)
+	if err != nil {
+		return err
+	}
+	if user.IsEmailVerified {
+		fmt.Printf("user %s is already verified\n", user.Email)
+		return nil
+	}
+	if err := a.Services.User.MarkEmailVerified(ctx, user.ID); err != nil {
+		return err
+	}
+
+	fmt.Printf("verified %s\n", user.Email)
+	return nil
+}
+
+func runUserResetPassword(ctx context.Context, args []string) error {
+	flags := newFlagSet("user reset-password")
+	identifier := flags.String("user", "", "user email or id")
+	passwordStdin := flags.Bool("password-stdin", false, "read the new password from stdin instead of generating one")
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	if *identifier == "" {
+		return errors.New("--user is required")
+	}
+
+	password, err := readPassword(*passwordStdin)
+	if err != nil {
+		return err
+	}
+
+	a, closeApp, err := openAdminApp()
+	if err != nil {
+		return err
+	}
+	defer closeApp()
+
+	user, err := a.Services.User.FindUser(ctx, *identifier)
+	if err != nil {
+		return err
+	}
+	if err := a.Services.User.SetPassword(ctx, user.ID, password); err != nil {
+		return err
+	}
+
+	fmt.Printf("password of %s changed\n", user.Email)
+	if !*passwordStdin {
+		fmt.Printf("password: %s\n", password)
+	}
+	return nil
+}
+
+func runUserDelete(ctx context.Context, args []string) error {
+	flags := newFlagSet("user delete")
+	identifier := flags.String("user", "", "user email or id")
+	confirmed := flags.Bool("yes", false, "confirm the deletion")
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	if *identifier == "" {
+		return errors.New("--user is required")
+	}
+
+	a, closeApp, err := openAdminApp()
+	if err != nil {
+		return err
+	}
+	defer closeApp()
+
+	user, err := a.Services.User.FindUser(ctx, *identifier)
+	if err != nil {
+		return err
+	}
+	if !*confirmed {
+		return fmt.Errorf("this deletes %s (%s) and all their data; rerun with --yes to confirm", user.Email, user.ID)
+	}
+	if err := a.Services.User.DeleteUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	fmt.Printf("deleted %s (%s)\n", user.Email, user.ID)
+	return nil
+}
+
+// readPassword returns the first line of stdin when fromStdin is set and a
+// generated password otherwise. Passwords are never taken as flags, where
+// they would end up in the shell history and the process list.
+func readPassword(fromStdin bool) (string, error) {
+	if !fromStdin {
+		return generatePassword()
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("no password on stdin")
+	}
+	return password, nil
+}
+
+// passwordClasses are the character classes a generated password mixes;
+// it holds at least one of each so it passes any password policy setting.
+var passwordClasses = []string{
+	"abcdefghijkmnopqrstuvwxyz",
+	"ABCDEFGHJKLMNPQRSTUVWXYZ",
+	"23456789",
+	"!#$%&*+-=?@_",
+}
+
+// generatedPasswordLen is the length of generated passwords.
+const generatedPasswordLen = 16
+
+// generatePassword returns a random password with at least one character of
+// every class in passwordClasses.
+func generatePassword() (string, error) {
+	all := strings.Join(passwordClasses, "")
+	password := make([]byte, 0, generatedPasswordLen)
+	for i := 0; i < generatedPasswordLen; i++ {
+		chars := all
+		if i < len(passwordClasses) {
+			chars = passwordClasses[i]
+		}
+		c, err := randomIndex(len(chars))
+		if err != nil {
+			return "", err
+		}
+		password = append(password, chars[c])
+	}
+
+	// Shuffle so the guaranteed characters are not always in front.
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomIndex(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
+
+func randomIndex(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate password: %w", err)
+	}
+	return int(i.Int64()), nil
+}
@@ -450,7 +450,6 @@ const docTemplate = `{
                 },
                 "new_password": {
                     "type": "string",
-                    "minLength": 8,
                     "example": "MyNewSecurePass123!"
                 }
             }
@@ -475,7 +474,6 @@ const docTemplate = `{
                 },
                 "password": {
                     "type": "string",
-                    "minLength": 8,
                     "example": "MySecurePass123!"
                 }
             }
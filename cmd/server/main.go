@@ -1,20 +1,29 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	swaggerfiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	_ "gin-backend-app/cmd/server/docs"
+	"gin-backend-app/internal/app"
 	"gin-backend-app/internal/config"
-	"gin-backend-app/internal/cron"
-	"gin-backend-app/internal/repositories"
+	"gin-backend-app/internal/metrics"
+	"gin-backend-app/internal/middleware"
 	"gin-backend-app/internal/routes"
-	"gin-backend-app/internal/services"
+	"gin-backend-app/internal/tracing"
+	"gin-backend-app/internal/validation"
 	"gin-backend-app/pkg/utils"
 )
 
@@ -34,92 +43,107 @@ import (
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 func main() {
+    if err := runCLI(os.Args[1:]); err != nil {
+        fatal("command failed", err)
+    }
+}
+
+// fatal logs err and exits; deferred calls do not run.
+func fatal(msg string, err error) {
+    slog.Error(msg, "error", err)
+    os.Exit(1)
+}
+
+// runServe starts the HTTP server and the cron scheduler, and shuts them
+// down in order on SIGINT or SIGTERM.
+func runServe() {
+    ctx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stopSignals()
+
+    cfg, err := loadConfig()
+    if err != nil {
+        fatal("failed to load configuration", err)
+    }
+    cfg.LogSummary()
+    utils.SetJWTSecret(cfg.JWT.Secret)
+
     // Set Gin mode based on environment
-    if os.Getenv("ENV") == "production" {
+    if cfg.IsProduction() {
         gin.SetMode(gin.ReleaseMode)
     }
 
-    log.Println("🚀 Starting Traspac Backend...")
+    slog.Info("starting traspac backend", "env", cfg.Env)
+
+    shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+    if err != nil {
+        fatal("failed to set up tracing", err)
+    }
+
+    if err := validation.Setup(); err != nil {
+        fatal("failed to set up request validation", err)
+    }
 
     // ======================================================================
     // 1. Initialize database
     // ======================================================================
-    log.Println("📦 Initializing database connection...")
-    db, err := config.InitDatabase()
+    db, err := config.InitDatabase(cfg.Database)
     if err != nil {
-        log.Fatal("❌ Failed to connect to database:", err)
+        fatal("failed to connect to database", err)
     }
 
     sqlDB, err := db.DB()
     if err != nil {
-        log.Fatal("❌ Failed to get SQL DB instance:", err)
+        fatal("failed to get SQL DB instance", err)
     }
-    defer func() {
-        if err := sqlDB.Close(); err != nil {
-            log.Printf("❌ Error closing database connection: %v", err)
-        } else {
-            log.Println("✅ Database connection closed successfully")
-        }
-    }()
 
     // Test database connection
     if err := sqlDB.Ping(); err != nil {
-        log.Fatal("❌ Failed to ping database:", err)
+        fatal("failed to ping database", err)
     }
-    log.Println("✅ Database connected and ping successful")
-    log.Printf("📊 Database Stats - Max Open Connections: %d", sqlDB.Stats().MaxOpenConnections)
-
-    // ======================================================================
-    // 2. Initialize repositories & services
-    // ======================================================================
-
-	baseURL := os.Getenv("BASE_URL")
-	mailer := utils.NewSMTPMailerFromEnv()
 
+    // Background goroutines stop when background is cancelled during
+    // shutdown; workers tracks them so the DB is closed only after they exit.
+    background, stopBackground := context.WithCancel(context.Background())
+    defer stopBackground()
+    var workers sync.WaitGroup
 
-    userRepo := repositories.NewUserRepository(db)
-    userTokenRepo := repositories.NewUserTokenRepository(db)
+    workers.Add(1)
+    go func() {
+        defer workers.Done()
+        config.MonitorPool(background, sqlDB)
+    }()
 
-    emailTokenService := services.NewEmailVerificationService(userRepo, userTokenRepo, mailer, baseURL)
+    if err := metrics.RegisterDBStats(sqlDB, "primary"); err != nil {
+        fatal("failed to register database metrics", err)
+    }
 
     // ======================================================================
-    // 3. Initialize cron scheduler
+    // 2. Build the application container
     // ======================================================================
-    scheduler := cron.NewScheduler(emailTokenService)
-    scheduler.Start()
-    defer scheduler.Stop()
+    a, err := app.New(cfg, db, app.Options{})
+    if err != nil {
+        fatal("failed to build application", err)
+    }
 
     // ======================================================================
-    // 4. Setup Gin router & routes
+    // 3. Start cron scheduler & notification listener
     // ======================================================================
-    router := gin.Default()
-    log.Println("🌐 Gin router initialized")
+    a.Scheduler.Start()
 
-    // Basic health check
-    router.GET("/health", func(c *gin.Context) {
-        c.JSON(http.StatusOK, gin.H{
-            "status":  "up",
-            "service": "traspac-backend",
-        })
-    })
-
-    // Database health check
-    router.GET("/health/db", func(c *gin.Context) {
-        if err := sqlDB.Ping(); err != nil {
-            c.JSON(http.StatusServiceUnavailable, gin.H{
-                "status":   "down",
-                "database": "disconnected",
-                "error":    err.Error(),
-            })
-            return
-        }
+    workers.Add(1)
+    go func() {
+        defer workers.Done()
+        a.Listener.Run(background)
+    }()
 
-        c.JSON(http.StatusOK, gin.H{
-            "status":   "up",
-            "database": "connected",
-            "service":  "traspac-backend",
-        })
-    })
+    // ======================================================================
+    // 4. Setup Gin router & routes
+    // ======================================================================
+    // Request ids and access logs come from our middleware instead of gin's
+    // logger; panics are recovered into a logged 500. The trace span wraps
+    // everything so log records carry its trace id.
+    router := gin.New()
+    router.Use(middleware.TracingMiddleware(), middleware.RequestIDMiddleware(), middleware.RequestLoggerMiddleware(), middleware.MetricsMiddleware(), gin.Recovery())
 
     // Root endpoint
     router.GET("/", func(c *gin.Context) {
@@ -132,8 +156,7 @@ func main() {
     })
 
     // API routes
-    routes.SetupRoutes(router, db)
-    log.Println("🛣️ Routes configured successfully")
+    routes.SetupRoutes(router, a)
 
     // Swagger docs
     router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
@@ -141,17 +164,91 @@ func main() {
     // ======================================================================
     // 5. Start HTTP server
     // ======================================================================
-    port := os.Getenv("PORT")
-    if port == "" {
-        port = "8080"
+    port := cfg.HTTP.Port
+
+    slog.Info("server starting", "port", port, "swagger", "/swagger/index.html")
+
+    server := &http.Server{
+        Addr:              ":" + port,
+        Handler:           router,
+        ReadHeaderTimeout: 10 * time.Second,
     }
 
-    log.Printf("🌟 Server starting on port :%s", port)
-    log.Printf("🔗 Health check: http://localhost:%s/health", port)
-    log.Printf("🔗 DB Health check: http://localhost:%s/health/db", port)
-    log.Printf("📚 Swagger docs: http://localhost:%s/swagger/index.html", port)
+    // Prometheus metrics get their own listener so they are not reachable
+    // through the public API port.
+    metricsServer := &http.Server{
+        Addr:              cfg.HTTP.MetricsAddr,
+        Handler:           metricsMux(),
+        ReadHeaderTimeout: 10 * time.Second,
+    }
 
-    if err := router.Run(":" + port); err != nil {
-        log.Fatalf("❌ Failed to run server: %v", err)
+    serverErr := make(chan error, 2)
+    go func() {
+        if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+            serverErr <- err
+        }
+    }()
+    if metricsServer.Addr != "" {
+        slog.Info("metrics server starting", "addr", metricsServer.Addr)
+        go func() {
+            if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+                serverErr <- fmt.Errorf("metrics server: %w", err)
+            }
+        }()
+    }
+
+    exitCode := 0
+    select {
+    case <-ctx.Done():
+        slog.Info("shutdown signal received")
+    case err := <-serverErr:
+        slog.Error("failed to run server", "error", err)
+        exitCode = 1
+    }
+    // A second signal kills the process immediately.
+    stopSignals()
+
+    // ======================================================================
+    // 6. Graceful shutdown, in reverse order of dependency
+    // ======================================================================
+    shutdown(cfg.HTTP.ShutdownTimeout,
+        shutdownStep{"notification streams", func(context.Context) error {
+            // SSE responses never go idle on their own, so end them first
+            // or the HTTP drain would wait for the full timeout.
+            a.Hub.Close()
+            return nil
+        }},
+        shutdownStep{"HTTP server", server.Shutdown},
+        shutdownStep{"metrics server", metricsServer.Shutdown},
+        shutdownStep{"cron jobs", func(ctx context.Context) error {
+            // Jobs still running when the timeout passes are cancelled so
+            // their queries end before the database is closed.
+            defer a.Scheduler.Cancel()
+            return waitDone(ctx, a.Scheduler.Stop().Done())
+        }},
+        shutdownStep{"background workers", func(ctx context.Context) error {
+            stopBackground()
+            done := make(chan struct{})
+            go func() {
+                workers.Wait()
+                close(done)
+            }()
+            return waitDone(ctx, done)
+        }},
+        shutdownStep{"database", func(context.Context) error {
+            return sqlDB.Close()
+        }},
+        shutdownStep{"tracing", shutdownTracing},
+    )
+
+    if exitCode != 0 {
+        os.Exit(exitCode)
     }
-}
\ No newline at end of file
+}
+
+// metricsMux serves the Prometheus metrics on the internal listener.
+func metricsMux() http.Handler {
+    mux := http.NewServeMux()
+    mux.Handle("/metrics", metrics.Handler())
+    return mux
+}
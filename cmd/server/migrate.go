@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"gin-backend-app/internal/config"
+	"gin-backend-app/internal/database"
+)
+
+const migrateUsage = `usage: server migrate <command>
+
+commands:
+  up              apply all pending migrations
+  down [-steps N] revert the last N migrations (default 1)
+  status          list migrations and whether they are applied
+  new <name>      create an empty up/down migration pair in ` + database.MigrationsDir
+
+// runMigrate implements `server migrate ...`.
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing migrate command\n%s", migrateUsage)
+	}
+
+	command, args := args[0], args[1:]
+	if command == "new" {
+		if len(args) != 1 {
+			return fmt.Errorf("migrate new takes exactly one name\n%s", migrateUsage)
+		}
+		upPath, downPath, err := database.NewMigrationFiles(database.MigrationsDir, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created %s\ncreated %s\n", upPath, downPath)
+		return nil
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	db, err := config.OpenDatabase(cfg.Database)
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	migrator, err := database.NewMigrator(sqlDB)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migrations applied\n", len(applied))
+
+	case "down":
+		flags := flag.NewFlagSet("migrate down", flag.ContinueOnError)
+		steps := flags.Int("steps", 1, "number of migrations to revert")
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		reverted, err := migrator.Down(ctx, *steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migrations reverted\n", len(reverted))
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT\tNOTE")
+		for _, status := range statuses {
+			appliedAt, note := "pending", ""
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Local().Format("2006-01-02 15:04:05")
+			}
+			switch {
+			case status.Missing:
+				note = "not in binary"
+			case status.Modified:
+				note = "checksum mismatch"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, appliedAt, note)
+		}
+		return w.Flush()
+
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", command, migrateUsage)
+	}
+	return nil
+}
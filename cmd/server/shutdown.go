@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// shutdownStep stops one component. Steps run in order and share a single
+// deadline, so a slow step leaves less time for the ones after it.
+type shutdownStep struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+func shutdown(timeout time.Duration, steps ...shutdownStep) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	slog.Info("shutting down", "timeout", timeout.String())
+	for _, step := range steps {
+		start := time.Now()
+		if err := step.stop(ctx); err != nil {
+			slog.Error("shutdown step failed", "step", step.name, "error", err)
+			continue
+		}
+		slog.Info("shutdown step done", "step", step.name, "duration_ms", time.Since(start).Milliseconds())
+	}
+	slog.Info("shutdown complete")
+}
+
+// waitDone waits for done or the deadline of ctx.
+func waitDone(ctx context.Context, done <-chan struct{}) error {
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,232 @@
+// Package app builds every repository, service, controller and background
+// worker exactly once. Routes, cron and the CLI take what they need from the
+// App instead of constructing their own copies.
+package app
+
+import (
+	"fmt"
+	"gin-backend-app/internal/config"
+	"gin-backend-app/internal/controllers"
+	"gin-backend-app/internal/cron"
+	"gin-backend-app/internal/health"
+	"gin-backend-app/internal/metrics"
+	"gin-backend-app/internal/realtime"
+	"gin-backend-app/internal/repositories"
+	"gin-backend-app/internal/services"
+	"gin-backend-app/internal/tracing"
+	"gin-backend-app/pkg/pwned"
+	"gin-backend-app/pkg/storage"
+	"gin-backend-app/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+type Repositories struct {
+	// Tx runs several repository calls as one database transaction.
+	Tx             repositories.TxManager
+	User           repositories.UserRepository
+	UserToken      repositories.UserTokenRepository
+	Category       repositories.CategoryRepository
+	Transaction    repositories.TransactionRepository
+	UserBudget     repositories.UserBudgetRepository
+	PeriodReport   repositories.PeriodReportRepository
+	Tag            repositories.TagRepository
+	SavingsGoal    repositories.SavingsGoalRepository
+	Attachment     repositories.AttachmentRepository
+	EmailOutbox    repositories.EmailOutboxRepository
+	Notification   repositories.NotificationRepository
+	BudgetAlert    repositories.BudgetAlertRepository
+	DigestDelivery repositories.DigestDeliveryRepository
+	AILog          repositories.AILogRepository
+}
+
+func NewRepositories(db *gorm.DB) *Repositories {
+	return &Repositories{
+		Tx:             repositories.NewTxManager(db),
+		User:           repositories.NewUserRepository(db),
+		UserToken:      repositories.NewUserTokenRepository(db),
+		Category:       repositories.NewCategoryRepository(db),
+		Transaction:    repositories.NewTransactionRepository(db),
+		UserBudget:     repositories.NewUserBudgetRepository(db),
+		PeriodReport:   repositories.NewPeriodReportRepository(db),
+		Tag:            repositories.NewTagRepository(db),
+		SavingsGoal:    repositories.NewSavingsGoalRepository(db),
+		Attachment:     repositories.NewAttachmentRepository(db),
+		EmailOutbox:    repositories.NewEmailOutboxRepository(db),
+		Notification:   repositories.NewNotificationRepository(db),
+		BudgetAlert:    repositories.NewBudgetAlertRepository(db),
+		DigestDelivery: repositories.NewDigestDeliveryRepository(db),
+		AILog:          repositories.NewAILogRepository(db),
+	}
+}
+
+type Services struct {
+	EmailOutbox       *services.EmailOutboxService
+	EmailVerification *services.EmailVerficationService
+	User              *services.UserService
+	Notification      *services.NotificationService
+	Report            *services.ReportService
+	Budget            *services.BudgetService
+	BudgetAlert       *services.BudgetAlertService
+	BudgetEvaluation  *services.BudgetEvaluationService
+	Transaction       *services.TransactionService
+	Tag               *services.TagService
+	SavingsGoal       *services.SavingsGoalService
+	Attachment        *services.AttachmentService
+	Digest            *services.DigestService
+}
+
+func NewServices(cfg *config.Config, repos *Repositories, mailer utils.Mailer, store storage.Storage, breached services.BreachedPasswords) *Services {
+	s := &Services{}
+
+	s.EmailOutbox = services.NewEmailOutboxService(repos.EmailOutbox, mailer, services.DefaultEmailOutboxConfig())
+	s.EmailVerification = services.NewEmailVerificationService(repos.Tx, repos.User, repos.UserToken, s.EmailOutbox, cfg.BaseURL)
+	s.Attachment = services.NewAttachmentService(repos.Attachment, repos.Transaction, store, attachmentConfig(cfg))
+	s.User = services.NewUserService(repos.Tx, repos.User, repos.UserToken, s.EmailVerification, passwordPolicy(cfg, breached), s.Attachment)
+	s.Notification = services.NewNotificationService(repos.Tx, repos.Notification, repos.User)
+
+	s.Report = services.NewReportService(repos.Transaction, repos.PeriodReport)
+	s.Budget = services.NewBudgetService(repos.UserBudget, repos.Transaction)
+	s.BudgetAlert = services.NewBudgetAlertService(repos.Tx, s.Budget, repos.BudgetAlert, repos.User, s.Notification, s.EmailOutbox, cfg.BudgetAlerts.Thresholds)
+	s.Transaction = services.NewTransactionService(repos.Tx, repos.Transaction, repos.Category, repos.Tag, s.BudgetAlert, s.Notification, s.Attachment)
+	s.Tag = services.NewTagService(repos.Tag)
+	s.SavingsGoal = services.NewSavingsGoalService(repos.SavingsGoal, repos.Category, repos.Transaction)
+	s.BudgetEvaluation = services.NewBudgetEvaluationService(s.Budget, s.SavingsGoal, repos.AILog)
+	s.Digest = services.NewDigestService(repos.Tx, repos.User, repos.DigestDelivery, s.Report, s.Budget, s.EmailOutbox)
+
+	return s
+}
+
+func attachmentConfig(cfg *config.Config) services.AttachmentConfig {
+	key := cfg.Attachments.SigningKey
+	if key == "" {
+		key = cfg.JWT.Secret
+	}
+	return services.AttachmentConfig{
+		MaxSize:    int64(cfg.Attachments.MaxSizeMB) << 20,
+		URLTTL:     cfg.Attachments.URLTTL,
+		BaseURL:    cfg.BaseURL,
+		SigningKey: []byte(key),
+	}
+}
+
+func passwordPolicy(cfg *config.Config, breached services.BreachedPasswords) services.PasswordPolicy {
+	return services.PasswordPolicy{
+		MinLength:      cfg.Password.MinLength,
+		MinCharClasses: cfg.Password.MinCharClasses,
+		Breached:       breached,
+	}
+}
+
+type Controllers struct {
+	User              *controllers.UserController
+	EmailVerification *controllers.EmailVerificationController
+	Transaction       *controllers.TransactionController
+	Report            *controllers.ReportController
+	Tag               *controllers.TagController
+	SavingsGoal       *controllers.SavingsGoalController
+	Attachment        *controllers.AttachmentController
+	Notification      *controllers.NotificationController
+	EmailPreview      *controllers.EmailPreviewController
+	Health            *controllers.HealthController
+}
+
+func NewControllers(s *Services, hub *realtime.Hub, checks *health.Registry) *Controllers {
+	return &Controllers{
+		User:              controllers.NewUserController(s.User),
+		EmailVerification: controllers.NewEmailVerificationController(s.EmailVerification),
+		Transaction:       controllers.NewTransactionController(s.Transaction),
+		Report:            controllers.NewReportController(s.Report, s.Budget, s.BudgetEvaluation),
+		Tag:               controllers.NewTagController(s.Tag),
+		SavingsGoal:       controllers.NewSavingsGoalController(s.SavingsGoal),
+		Attachment:        controllers.NewAttachmentController(s.Attachment),
+		Notification:      controllers.NewNotificationController(s.Notification, hub),
+		EmailPreview:      controllers.NewEmailPreviewController(),
+		Health:            controllers.NewHealthController(checks),
+	}
+}
+
+// App is the fully wired application.
+type App struct {
+	Config       *config.Config
+	DB           *gorm.DB
+	Mailer       utils.Mailer
+	Storage      storage.Storage
+	Hub          *realtime.Hub
+	Repositories *Repositories
+	Services     *Services
+	Health       *health.Registry
+	Controllers  *Controllers
+	// Scheduler and Listener are built but not started; the server starts
+	// them and the CLI does not.
+	Scheduler *cron.Scheduler
+	Listener  *realtime.Listener
+}
+
+// Options replaces external dependencies, e.g. with fakes in tests. Nil
+// fields are built from the config.
+type Options struct {
+	Mailer  utils.Mailer
+	Storage storage.Storage
+}
+
+func New(cfg *config.Config, db *gorm.DB, opts Options) (*App, error) {
+	mailer := opts.Mailer
+	if mailer == nil {
+		var err error
+		if mailer, err = utils.NewMailer(cfg.Mail); err != nil {
+			return nil, fmt.Errorf("failed to initialize mailer: %w", err)
+		}
+	}
+	// Health checks ping the mail server directly; sends are instrumented.
+	rawMailer := mailer
+	mailer = metrics.InstrumentMailer(tracing.TraceMailer(mailer, cfg.Mail.Driver))
+
+	store := opts.Storage
+	if store == nil {
+		var err error
+		if store, err = storage.NewStorage(cfg.Storage); err != nil {
+			return nil, fmt.Errorf("failed to initialize attachment storage: %w", err)
+		}
+	}
+
+	var breached services.BreachedPasswords
+	if cfg.Password.BreachedDir != "" {
+		dataset, err := pwned.Open(cfg.Password.BreachedDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open breached password dataset: %w", err)
+		}
+		breached = dataset
+	}
+
+	repos := NewRepositories(db)
+	svc := NewServices(cfg, repos, mailer, store, breached)
+
+	// Events are published with NOTIFY by whichever instance handled the
+	// write; every instance listens and serves its own SSE clients.
+	hub := realtime.NewHub()
+
+	scheduler := cron.NewScheduler(svc.EmailVerification, svc.EmailOutbox, svc.Digest)
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	checks, err := NewHealth(cfg, sqlDB, rawMailer, scheduler)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize health checks: %w", err)
+	}
+
+	return &App{
+		Config:       cfg,
+		DB:           db,
+		Mailer:       mailer,
+		Storage:      store,
+		Hub:          hub,
+		Repositories: repos,
+		Services:     svc,
+		Health:       checks,
+		Controllers:  NewControllers(svc, hub, checks),
+		Scheduler:    scheduler,
+		Listener:     realtime.NewListener(cfg.Database.DSN(), hub),
+	}, nil
+}
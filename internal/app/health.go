@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"gin-backend-app/internal/config"
+	"gin-backend-app/internal/cron"
+	"gin-backend-app/internal/database"
+	"gin-backend-app/internal/health"
+	"gin-backend-app/pkg/utils"
+)
+
+// pinger is implemented by mailers that can check their server without
+// sending mail.
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// NewHealth registers the readiness checks. The database and schema are
+// critical: without them no request can be served. The mail server,
+// scheduler and attachment disk only degrade the service, since mail waits
+// in the outbox and most endpoints keep working.
+func NewHealth(cfg *config.Config, sqlDB *sql.DB, mailer utils.Mailer, scheduler *cron.Scheduler) (*health.Registry, error) {
+	migrator, err := database.NewMigrator(sqlDB)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := health.NewRegistry(cfg.Health.CheckTimeout, cfg.Health.CacheTTL)
+	checks.Register("database", true, sqlDB.PingContext)
+	checks.Register("migrations", true, func(ctx context.Context) error {
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		var pending, modified, missing int
+		for _, status := range statuses {
+			switch {
+			case status.Missing:
+				missing++
+			case status.Modified:
+				modified++
+			case status.AppliedAt == nil:
+				pending++
+			}
+		}
+		if pending > 0 || modified > 0 || missing > 0 {
+			return fmt.Errorf("%d migrations are not applied, %d differ from their files and %d are unknown to this binary",
+				pending, modified, missing)
+		}
+		return nil
+	})
+	checks.Register("cron", false, func(context.Context) error {
+		if !scheduler.Running() {
+			return errors.New("scheduler is not running")
+		}
+		return nil
+	})
+	if p, ok := mailer.(pinger); ok {
+		checks.Register("smtp", false, p.Ping)
+	}
+	if cfg.Storage.Driver == "local" {
+		checks.Register("disk", false, health.DiskSpace(cfg.Storage.LocalDir, uint64(cfg.Health.MinFreeDiskMB)<<20))
+	}
+	return checks, nil
+}
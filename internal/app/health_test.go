@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gin-backend-app/internal/config"
+	"gin-backend-app/internal/cron"
+	"gin-backend-app/internal/database"
+	"gin-backend-app/internal/health"
+	"gin-backend-app/internal/testutil/pgtest"
+)
+
+func TestHealthFailsOnDriftedMigrations(t *testing.T) {
+	db := pgtest.New(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{Health: config.HealthConfig{CheckTimeout: 5 * time.Second}}
+	// The scheduler is never started, so the cron check always fails.
+	scheduler := cron.NewScheduler(nil, nil, nil)
+
+	checks, err := NewHealth(cfg, sqlDB, nil, scheduler)
+	if err != nil {
+		t.Fatalf("NewHealth: %v", err)
+	}
+
+	report := checks.Run(context.Background())
+	if report.Status != health.StatusDegraded {
+		t.Errorf("status = %s, want degraded by the stopped scheduler", report.Status)
+	}
+	for name, want := range map[string]string{"database": health.StatusUp, "migrations": health.StatusUp, "cron": health.StatusDown} {
+		if got := report.Checks[name].Status; got != want {
+			t.Errorf("%s = %s, want %s", name, got, want)
+		}
+	}
+	if _, ok := report.Checks["smtp"]; ok {
+		t.Error("smtp check registered for a mailer without Ping")
+	}
+
+	// A version this binary does not know about, as after a rollback to an
+	// older release.
+	if err := db.Exec(`INSERT INTO schema_migrations (version, name, checksum) VALUES (9999, 'from_the_future', 'x')`).Error; err != nil {
+		t.Fatal(err)
+	}
+	report = checks.Run(context.Background())
+	if report.Status != health.StatusDown || report.Checks["migrations"].Status != health.StatusDown {
+		t.Errorf("report = %+v, want down on an unknown migration", report)
+	}
+
+	if err := db.Exec(`DELETE FROM schema_migrations WHERE version = 9999`).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec(`UPDATE schema_migrations SET checksum = 'edited' WHERE version = 1`).Error; err != nil {
+		t.Fatal(err)
+	}
+	if report := checks.Run(context.Background()); report.Checks["migrations"].Status != health.StatusDown {
+		t.Errorf("migrations = %s, want down on a checksum mismatch", report.Checks["migrations"].Status)
+	}
+
+	if err := db.Exec(`DELETE FROM schema_migrations WHERE version = (SELECT max(version) FROM schema_migrations)`).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec(`UPDATE schema_migrations SET checksum = ? WHERE version = 1`, firstChecksum(t)).Error; err != nil {
+		t.Fatal(err)
+	}
+	if report := checks.Run(context.Background()); report.Checks["migrations"].Status != health.StatusDown {
+		t.Errorf("migrations = %s, want down on a pending migration", report.Checks["migrations"].Status)
+	}
+}
+
+func firstChecksum(t *testing.T) string {
+	t.Helper()
+	migrations, err := database.LoadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return migrations[0].Checksum
+}
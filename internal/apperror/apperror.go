@@ -0,0 +1,182 @@
+// Package apperror defines the errors services return to API clients: a
+// stable machine-readable code, the HTTP status it maps to, a message, and
+// optional field-level details. Clients localize on the code, not the
+// message.
+package apperror
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Code identifies an error for clients. Codes are part of the API and must
+// not be renamed.
+type Code string
+
+const (
+	CodeInvalidRequest   Code = "INVALID_REQUEST"
+	CodeValidationFailed Code = "VALIDATION_FAILED"
+
+	CodeAuthRequired           Code = "AUTH_REQUIRED"
+	CodeAuthInvalidCredentials Code = "AUTH_INVALID_CREDENTIALS"
+	CodeAuthTokenInvalid       Code = "AUTH_TOKEN_INVALID"
+	CodeForbidden              Code = "FORBIDDEN"
+
+	CodeOTPInvalid           Code = "OTP_INVALID"
+	CodeOTPExpired           Code = "OTP_EXPIRED"
+	CodeOTPAlreadyUsed       Code = "OTP_ALREADY_USED"
+	CodeEmailAlreadyVerified Code = "EMAIL_ALREADY_VERIFIED"
+	CodePasswordTooWeak      Code = "PASSWORD_TOO_WEAK"
+	CodePasswordBreached     Code = "PASSWORD_BREACHED"
+
+	CodeUserNotFound         Code = "USER_NOT_FOUND"
+	CodeUserEmailTaken       Code = "USER_EMAIL_TAKEN"
+	CodeUserNameTaken        Code = "USER_NAME_TAKEN"
+	CodeTransactionNotFound  Code = "TRANSACTION_NOT_FOUND"
+	CodeAttachmentNotFound   Code = "ATTACHMENT_NOT_FOUND"
+	CodeAttachmentTooLarge   Code = "ATTACHMENT_TOO_LARGE"
+	CodeAttachmentURLInvalid Code = "ATTACHMENT_URL_INVALID"
+	CodeTagNotFound          Code = "TAG_NOT_FOUND"
+	CodeTagAlreadyExists     Code = "TAG_ALREADY_EXISTS"
+	CodeSavingsGoalNotFound  Code = "SAVINGS_GOAL_NOT_FOUND"
+	CodeNotificationNotFound Code = "NOTIFICATION_NOT_FOUND"
+	CodeNotFound             Code = "NOT_FOUND"
+	CodePayloadTooLarge      Code = "PAYLOAD_TOO_LARGE"
+	CodeRateLimited          Code = "RATE_LIMITED"
+	CodeRequestTimeout       Code = "REQUEST_TIMEOUT"
+	CodeServiceUnavailable   Code = "SERVICE_UNAVAILABLE"
+	CodeInternal             Code = "INTERNAL_ERROR"
+)
+
+var statuses = map[Code]int{
+	CodeInvalidRequest:   http.StatusBadRequest,
+	CodeValidationFailed: http.StatusBadRequest,
+
+	CodeAuthRequired:           http.StatusUnauthorized,
+	CodeAuthInvalidCredentials: http.StatusUnauthorized,
+	CodeAuthTokenInvalid:       http.StatusUnauthorized,
+	CodeForbidden:              http.StatusForbidden,
+
+	CodeOTPInvalid:           http.StatusBadRequest,
+	CodeOTPExpired:           http.StatusBadRequest,
+	CodeOTPAlreadyUsed:       http.StatusBadRequest,
+	CodeEmailAlreadyVerified: http.StatusConflict,
+	CodePasswordTooWeak:      http.StatusBadRequest,
+	CodePasswordBreached:     http.StatusBadRequest,
+
+	CodeUserNotFound:         http.StatusNotFound,
+	CodeUserEmailTaken:       http.StatusConflict,
+	CodeUserNameTaken:        http.StatusConflict,
+	CodeTransactionNotFound:  http.StatusNotFound,
+	CodeAttachmentNotFound:   http.StatusNotFound,
+	CodeAttachmentTooLarge:   http.StatusRequestEntityTooLarge,
+	CodeAttachmentURLInvalid: http.StatusForbidden,
+	CodeTagNotFound:          http.StatusNotFound,
+	CodeTagAlreadyExists:     http.StatusConflict,
+	CodeSavingsGoalNotFound:  http.StatusNotFound,
+	CodeNotificationNotFound: http.StatusNotFound,
+	CodeNotFound:             http.StatusNotFound,
+	CodePayloadTooLarge:      http.StatusRequestEntityTooLarge,
+	CodeRateLimited:          http.StatusTooManyRequests,
+	CodeRequestTimeout:       http.StatusGatewayTimeout,
+	CodeServiceUnavailable:   http.StatusServiceUnavailable,
+	CodeInternal:             http.StatusInternalServerError,
+}
+
+// Status returns the HTTP status of the code, 500 for unknown codes.
+func (c Code) Status() int {
+	if status, ok := statuses[c]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// CodeForStatus returns the generic code of an HTTP status, for responses
+// written without a service error.
+func CodeForStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeInvalidRequest
+	case http.StatusUnauthorized:
+		return CodeAuthRequired
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusTooManyRequests:
+		return CodeRateLimited
+	case http.StatusServiceUnavailable:
+		return CodeServiceUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeRequestTimeout
+	default:
+		return CodeInternal
+	}
+}
+
+// FieldError describes one invalid field of a request.
+type FieldError struct {
+	// Field is the JSON name of the field, e.g. "confirm_password".
+	Field string `json:"field" example:"email"`
+	// Rule is the rule the value broke, e.g. "required" or "email".
+	Rule    string `json:"rule" example:"required"`
+	Message string `json:"message" example:"email is required"`
+}
+
+// Error is an error with a code. Message is safe to show to clients; the
+// wrapped cause is only logged.
+type Error struct {
+	Code    Code
+	Message string
+	Details []FieldError
+	cause   error
+}
+
+// New returns an error with code and message. Services declare the errors
+// callers test for as package variables made with New.
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Wrap returns an error with code and message that unwraps to cause.
+func Wrap(code Code, message string, cause error) *Error {
+	return &Error{Code: code, Message: message, cause: cause}
+}
+
+// Validation returns a VALIDATION_FAILED error listing the invalid fields.
+func Validation(message string, details ...FieldError) *Error {
+	return &Error{Code: CodeValidationFailed, Message: message, Details: details}
+}
+
+// Field returns a VALIDATION_FAILED error for a single field.
+func Field(field, rule, message string) *Error {
+	return Validation(message, FieldError{Field: field, Rule: rule, Message: message})
+}
+
+// Internal hides cause behind a generic message.
+func Internal(cause error) *Error {
+	return Wrap(CodeInternal, "Internal server error", cause)
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// From returns the *Error in err's chain. Any other error, such as a failed
+// query, becomes INTERNAL_ERROR so its text never reaches the client.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return Internal(err)
+}
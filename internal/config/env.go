@@ -0,0 +1,129 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyEnv overrides fields of cfg from the environment variables named by
+// their env tags. Empty variables are ignored, since docker-compose passes
+// unset ${VARS} through as empty strings.
+func applyEnv(cfg *Config) error {
+	return applyEnvValue(reflect.ValueOf(cfg).Elem())
+}
+
+func applyEnvValue(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			if err := applyEnvValue(value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name := field.Tag.Get("env")
+		if name == "" {
+			continue
+		}
+		raw := strings.TrimSpace(os.Getenv(name))
+		if raw == "" {
+			continue
+		}
+		if err := setFromString(value, raw); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func setFromString(value reflect.Value, raw string) error {
+	if value.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(d))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(n))
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.Int {
+			return fmt.Errorf("unsupported list type %s", value.Type())
+		}
+		var items []int
+		for _, part := range strings.Split(raw, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return fmt.Errorf("invalid list item %q", part)
+			}
+			items = append(items, n)
+		}
+		value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
+
+// Redacted renders the configuration one "path: value" per line with secret
+// fields masked, for logging at startup.
+func (c *Config) Redacted() string {
+	var b strings.Builder
+	writeRedacted(&b, "", reflect.ValueOf(*c))
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func writeRedacted(b *strings.Builder, prefix string, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			name = strings.ToLower(field.Name)
+		}
+		path := prefix + name
+
+		if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+			writeRedacted(b, path+".", value)
+			continue
+		}
+
+		var shown string
+		switch {
+		case field.Tag.Get("secret") == "true" && !value.IsZero():
+			shown = "******"
+		case value.Type() == durationType:
+			shown = time.Duration(value.Int()).String()
+		default:
+			shown = fmt.Sprint(value.Interface())
+		}
+		fmt.Fprintf(b, "  %s: %s\n", path, shown)
+	}
+}
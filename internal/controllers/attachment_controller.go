@@ -0,0 +1,233 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"gin-backend-app/internal/dto/common"
+	"gin-backend-app/internal/services"
+	"gin-backend-app/pkg/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// multipartOverhead is the room left above the attachment size limit for
+// the multipart boundaries and part headers.
+const multipartOverhead = 64 << 10
+
+type AttachmentController struct {
+	AttachmentService *services.AttachmentService
+}
+
+func NewAttachmentController(attachmentService *services.AttachmentService) *AttachmentController {
+	return &AttachmentController{
+		AttachmentService: attachmentService,
+	}
+}
+
+// UploadAttachment godoc
+// @Summary Upload receipt attachment
+// @Description Attach a receipt photo (JPEG, PNG, WebP) or PDF to a transaction. The file type is detected from its content. Images get a thumbnail.
+// @Tags Attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Transaction ID"
+// @Param file formData file true "Receipt file"
+// @Success 201 {object} common.Response{data=response.AttachmentResponse} "Attachment uploaded successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid or unsupported file"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Failure 404 {object} common.ErrorResponse "Transaction not found"
+// @Failure 413 {object} common.ErrorResponse "File too large"
+// @Security BearerAuth
+// @Router /transactions/{id}/attachments [post]
+func (ac *AttachmentController) UploadAttachment(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.SendError(c, http.StatusBadRequest, "Invalid transaction ID")
+		return
+	}
+
+	// Stop reading as soon as the body exceeds the limit instead of
+	// spooling the whole upload before rejecting it.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, ac.AttachmentService.Config.MaxSize+multipartOverhead)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.Error(services.ErrAttachmentTooLarge)
+			return
+		}
+		common.SendError(c, http.StatusBadRequest, "File is required")
+		return
+	}
+	if fileHeader.Size > ac.AttachmentService.Config.MaxSize {
+		c.Error(services.ErrAttachmentTooLarge)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		common.SendError(c, http.StatusBadRequest, "Failed to read file")
+		return
+	}
+	defer file.Close()
+
+	attachment, err := ac.AttachmentService.Upload(c.Request.Context(), userID, transactionID, fileHeader.Filename, file)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusCreated, attachment, "Attachment uploaded successfully")
+}
+
+// ListAttachments godoc
+// @Summary List transaction attachments
+// @Description List attachments of a transaction with short-lived signed download URLs
+// @Tags Attachments
+// @Produce json
+// @Param id path string true "Transaction ID"
+// @Success 200 {object} common.Response{data=[]response.AttachmentResponse} "Attachments retrieved successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid transaction ID"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Failure 404 {object} common.ErrorResponse "Transaction not found"
+// @Security BearerAuth
+// @Router /transactions/{id}/attachments [get]
+func (ac *AttachmentController) ListAttachments(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.SendError(c, http.StatusBadRequest, "Invalid transaction ID")
+		return
+	}
+
+	attachments, err := ac.AttachmentService.ListAttachments(c.Request.Context(), userID, transactionID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, attachments, "Attachments retrieved successfully")
+}
+
+// GetAttachment godoc
+// @Summary Get attachment download URLs
+// @Description Issue fresh short-lived signed URLs for an attachment owned by the authenticated user
+// @Tags Attachments
+// @Produce json
+// @Param id path string true "Attachment ID"
+// @Success 200 {object} common.Response{data=response.AttachmentResponse} "Attachment retrieved successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid attachment ID"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Failure 404 {object} common.ErrorResponse "Attachment not found"
+// @Security BearerAuth
+// @Router /attachments/{id} [get]
+func (ac *AttachmentController) GetAttachment(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	attachmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.SendError(c, http.StatusBadRequest, "Invalid attachment ID")
+		return
+	}
+
+	attachment, err := ac.AttachmentService.GetAttachment(c.Request.Context(), userID, attachmentID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, attachment, "Attachment retrieved successfully")
+}
+
+// DownloadAttachment godoc
+// @Summary Download attachment
+// @Description Stream an attachment or its thumbnail using a signed URL issued by the list or get endpoints. No Authorization header is required.
+// @Tags Attachments
+// @Produce octet-stream
+// @Param id path string true "Attachment ID"
+// @Param variant query string false "original or thumbnail" default(original)
+// @Param expires query int true "Expiry as unix timestamp"
+// @Param signature query string true "URL signature"
+// @Success 200 {file} file "Attachment content"
+// @Failure 403 {object} common.ErrorResponse "Invalid or expired URL"
+// @Failure 404 {object} common.ErrorResponse "Attachment not found"
+// @Router /attachments/{id}/content [get]
+func (ac *AttachmentController) DownloadAttachment(c *gin.Context) {
+	attachmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.SendError(c, http.StatusNotFound, "Attachment not found")
+		return
+	}
+
+	variant := c.DefaultQuery("variant", services.AttachmentVariantOriginal)
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		c.Error(services.ErrInvalidSignature)
+		return
+	}
+
+	reader, attachment, contentType, err := ac.AttachmentService.OpenSigned(c.Request.Context(), attachmentID, variant, expires, c.Query("signature"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer reader.Close()
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", attachment.FileName))
+	c.DataFromReader(http.StatusOK, -1, contentType, reader, nil)
+}
+
+// DeleteAttachment godoc
+// @Summary Delete attachment
+// @Description Delete an attachment and its stored files
+// @Tags Attachments
+// @Produce json
+// @Param id path string true "Attachment ID"
+// @Success 200 {object} common.Response "Attachment deleted successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid attachment ID"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Failure 404 {object} common.ErrorResponse "Attachment not found"
+// @Security BearerAuth
+// @Router /attachments/{id} [delete]
+func (ac *AttachmentController) DeleteAttachment(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	attachmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.SendError(c, http.StatusBadRequest, "Invalid attachment ID")
+		return
+	}
+
+	if err := ac.AttachmentService.DeleteAttachment(c.Request.Context(), userID, attachmentID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, gin.H{
+		"id": attachmentID,
+	}, "Attachment deleted successfully")
+}
@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"errors"
+	"gin-backend-app/internal/apperror"
+	"gin-backend-app/internal/dto/common"
+	"gin-backend-app/pkg/mailtemplate"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errEmailTemplateNotFound = apperror.New(apperror.CodeNotFound, "Email template not found")
+
+// EmailPreviewController renders email templates with sample data so they
+// can be checked in a browser. Only registered outside production.
+type EmailPreviewController struct{}
+
+func NewEmailPreviewController() *EmailPreviewController {
+	return &EmailPreviewController{}
+}
+
+// ListEmailTemplates godoc
+// @Summary List email templates (dev only)
+// @Description List the email templates and locales available for preview. Not available in production.
+// @Tags Dev
+// @Produce json
+// @Success 200 {object} common.Response "Email templates retrieved successfully"
+// @Router /dev/emails [get]
+func (ec *EmailPreviewController) ListEmailTemplates(c *gin.Context) {
+	common.SendResponse(c, http.StatusOK, gin.H{
+		"templates": mailtemplate.Names(),
+		"locales":   mailtemplate.Locales(),
+	}, "Email templates retrieved successfully")
+}
+
+// PreviewEmail godoc
+// @Summary Preview email template (dev only)
+// @Description Render an email template with sample data. Not available in production.
+// @Tags Dev
+// @Produce html
+// @Produce plain
+// @Param name path string true "Template name"
+// @Param locale query string false "Locale (en or id)" default(en)
+// @Param format query string false "html or text" default(html)
+// @Success 200 {string} string "Rendered email"
+// @Failure 404 {object} common.ErrorResponse "Unknown template"
+// @Router /dev/emails/{name} [get]
+func (ec *EmailPreviewController) PreviewEmail(c *gin.Context) {
+	email, err := mailtemplate.Preview(c.Param("name"), c.Query("locale"))
+	if errors.Is(err, mailtemplate.ErrUnknownTemplate) {
+		c.Error(errEmailTemplateNotFound)
+		return
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("X-Email-Subject", email.Subject)
+	if c.Query("format") == "text" {
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(email.Text))
+		return
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(email.HTML))
+}
@@ -5,8 +5,9 @@ import (
 	"gin-backend-app/internal/dto/request"
 	"gin-backend-app/internal/models"
 	"gin-backend-app/internal/services"
+	"gin-backend-app/internal/validation"
 	"gin-backend-app/pkg/utils"
-	"log"
+	"log/slog"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -38,47 +39,34 @@ func NewEmailVerificationController(emailVerificationService *services.EmailVerf
 func (evc *EmailVerificationController) VerifyEmail(c *gin.Context) {
 	var req request.OtpVerificationRequest
 
-	log.Printf("DEBUG: Starting email verification")
-
 	userID, err := utils.GetUserIDFromContext(c)
 	if err != nil {
-		log.Printf("DEBUG: GetUserIDFromContext error: %v", err)
 		common.SendError(c, http.StatusUnauthorized, "Authentication required")
 		return
 	}
 
-	log.Printf("DEBUG: UserID from context: %s", userID)
-
-	user, err := evc.EmailVerificationService.GetUserByID(userID)
+	user, err := evc.EmailVerificationService.GetUserByID(c.Request.Context(), userID)
 	if err != nil {
-		log.Printf("DEBUG: GetUserByID error: %v", err)
-		common.SendError(c, http.StatusNotFound, "User not found")
+		c.Error(err)
 		return
 	}
 
-	log.Printf("DEBUG: User found: %s (%s)", user.Name, user.Email)
-
 	if err := c.ShouldBindJSON(&req); err != nil {
-		log.Printf("DEBUG: JSON binding error: %v", err)
-		common.SendError(c, http.StatusBadRequest, "Invalid request body")
+		c.Error(validation.BindError(c, err))
 		return
 	}
 
 	if req.TokenOtp == "" {
-		log.Printf("DEBUG: Empty OTP token")
 		common.SendError(c, http.StatusBadRequest, "OTP token is required")
 		return
 	}
 
-	log.Printf("DEBUG: Attempting to verify OTP: %s", req.TokenOtp)
-
-	if err := evc.EmailVerificationService.VerifiyEmail(req.TokenOtp, user.ID); err != nil {
-		log.Printf("DEBUG: VerifiyEmail error: %v", err)
-		common.SendError(c, http.StatusBadRequest, err.Error())
+	if err := evc.EmailVerificationService.VerifiyEmail(c.Request.Context(), req.TokenOtp, user.ID); err != nil {
+		slog.DebugContext(c.Request.Context(), "email verification failed", "error", err)
+		c.Error(err)
 		return
 	}
 
-	log.Printf("DEBUG: Email verification successful")
 	common.SendResponse(c, http.StatusOK, gin.H{
 		"email":   user.Email,
 		"message": "Email verified successfully",
@@ -92,7 +80,7 @@ func (evc *EmailVerificationController) VerifyEmail(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} common.Response "Verification email sent successfully"
-// @Failure 400 {object} common.ErrorResponse "Rate limit exceeded or email already verified"
+// @Failure 409 {object} common.ErrorResponse "Email already verified"
 // @Failure 401 {object} common.ErrorResponse "Authentication required"
 // @Failure 404 {object} common.ErrorResponse "User not found"
 // @Failure 429 {object} common.ErrorResponse "Too many requests - rate limited"
@@ -105,19 +93,14 @@ func (evc *EmailVerificationController) ResendEmailVerification(c *gin.Context)
 		return
 	}
 
-	user, err := evc.EmailVerificationService.GetUserByID(userID)
+	user, err := evc.EmailVerificationService.GetUserByID(c.Request.Context(), userID)
 	if err != nil {
-		common.SendError(c, http.StatusNotFound, "User not found")
-		return
-	}
-
-	if user.IsEmailVerified {
-		common.SendError(c, http.StatusBadRequest, "Email is already verified")
+		c.Error(err)
 		return
 	}
 
-	if err := evc.EmailVerificationService.SendEmailVerification(user, models.TokenTypeEmailVerification); err != nil {
-		common.SendError(c, http.StatusBadRequest, err.Error())
+	if err := evc.EmailVerificationService.ResendEmailverification(c.Request.Context(), user, models.TokenTypeEmailVerification); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -145,9 +128,9 @@ func (evc *EmailVerificationController) CheckVerificationStatus(c *gin.Context)
 		return
 	}
 
-	user, err := evc.EmailVerificationService.GetUserByID(userID)
+	user, err := evc.EmailVerificationService.GetUserByID(c.Request.Context(), userID)
 	if err != nil {
-		common.SendError(c, http.StatusNotFound, "User not found")
+		c.Error(err)
 		return
 	}
 
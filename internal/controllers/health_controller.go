@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"gin-backend-app/internal/health"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthController struct {
+	Health    *health.Registry
+	startedAt time.Time
+}
+
+func NewHealthController(registry *health.Registry) *HealthController {
+	return &HealthController{
+		Health:    registry,
+		startedAt: time.Now(),
+	}
+}
+
+// Livez answers as long as the process serves HTTP. It checks no
+// dependency, so an orchestrator never restarts the service because the
+// database is down.
+func (hc *HealthController) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":         health.StatusUp,
+		"service":        "traspac-backend",
+		"uptime_seconds": int64(time.Since(hc.startedAt).Seconds()),
+	})
+}
+
+// Readyz reports every dependency check. It responds 503 while a critical
+// check fails so load balancers stop routing traffic here.
+func (hc *HealthController) Readyz(c *gin.Context) {
+	report := hc.Health.Run(c.Request.Context())
+
+	status := http.StatusOK
+	if report.Status == health.StatusDown {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
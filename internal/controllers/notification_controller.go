@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"gin-backend-app/internal/dto/common"
+	"gin-backend-app/internal/dto/request"
+	"gin-backend-app/internal/realtime"
+	"gin-backend-app/internal/services"
+	"gin-backend-app/internal/validation"
+	"gin-backend-app/pkg/utils"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// streamHeartbeat keeps idle SSE connections open through proxies.
+const streamHeartbeat = 25 * time.Second
+
+type NotificationController struct {
+	NotificationService *services.NotificationService
+	Hub                 *realtime.Hub
+}
+
+func NewNotificationController(notificationService *services.NotificationService, hub *realtime.Hub) *NotificationController {
+	return &NotificationController{
+		NotificationService: notificationService,
+		Hub:                 hub,
+	}
+}
+
+// ListNotifications godoc
+// @Summary List notifications
+// @Description Get paginated in-app notifications of the authenticated user, newest first, with the unread count
+// @Tags Notifications
+// @Produce json
+// @Param unread query bool false "Only unread notifications"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Page size" default(20)
+// @Success 200 {object} common.Response{data=response.NotificationListResponse} "Notifications retrieved successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /notifications [get]
+func (nc *NotificationController) ListNotifications(c *gin.Context) {
+	var query request.ListNotificationsQuery
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(validation.BindError(c, err))
+		return
+	}
+
+	notifications, err := nc.NotificationService.ListNotifications(c.Request.Context(), userID, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, notifications, "Notifications retrieved successfully")
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark notification as read
+// @Description Mark a single notification of the authenticated user as read
+// @Tags Notifications
+// @Produce json
+// @Param id path string true "Notification ID"
+// @Success 200 {object} common.Response "Notification marked as read"
+// @Failure 400 {object} common.ErrorResponse "Invalid notification ID"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Failure 404 {object} common.ErrorResponse "Notification not found"
+// @Security BearerAuth
+// @Router /notifications/{id}/read [patch]
+func (nc *NotificationController) MarkNotificationRead(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	notificationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.SendError(c, http.StatusBadRequest, "Invalid notification ID")
+		return
+	}
+
+	if err := nc.NotificationService.MarkRead(c.Request.Context(), userID, notificationID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, nil, "Notification marked as read")
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Mark all notifications as read
+// @Description Mark every unread notification of the authenticated user as read
+// @Tags Notifications
+// @Produce json
+// @Success 200 {object} common.Response{data=response.NotificationReadAllResponse} "Notifications marked as read"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /notifications/read-all [post]
+func (nc *NotificationController) MarkAllNotificationsRead(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	result, err := nc.NotificationService.MarkAllRead(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, result, "Notifications marked as read")
+}
+
+// StreamNotifications godoc
+// @Summary Stream notifications
+// @Description Server-Sent Events stream of the authenticated user's new notifications. A "ready" event with the unread count is sent first, then a "notification" event per new notification; comment lines are sent as heartbeats.
+// @Tags Notifications
+// @Produce text/event-stream
+// @Success 200 {string} string "Event stream"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /notifications/stream [get]
+func (nc *NotificationController) StreamNotifications(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	unread, err := nc.NotificationService.UnreadCount(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	events, unsubscribe := nc.Hub.Subscribe(userID)
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.SSEvent("ready", gin.H{"unread": unread})
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Type, event.Data)
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"gin-backend-app/internal/dto/common"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/services"
+	"gin-backend-app/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportController struct {
+	ReportService           *services.ReportService
+	BudgetService           *services.BudgetService
+	BudgetEvaluationService *services.BudgetEvaluationService
+}
+
+func NewReportController(reportService *services.ReportService, budgetService *services.BudgetService, budgetEvaluationService *services.BudgetEvaluationService) *ReportController {
+	return &ReportController{
+		ReportService:           reportService,
+		BudgetService:           budgetService,
+		BudgetEvaluationService: budgetEvaluationService,
+	}
+}
+
+// GetPeriodReport godoc
+// @Summary Get weekly or monthly report
+// @Description Generate the report for the week or month containing the given date. Category totals count split lines separately.
+// @Tags Reports
+// @Produce json
+// @Param period_type path string true "weekly or monthly"
+// @Param date query string false "Any date within the period (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} common.Response{data=response.PeriodReportResponse} "Report generated successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid period type or date"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /reports/{period_type} [get]
+func (rc *ReportController) GetPeriodReport(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	periodType := models.PeriodType(c.Param("period_type"))
+	if periodType != models.PeriodWeekly && periodType != models.PeriodMonthly {
+		common.SendError(c, http.StatusBadRequest, "Period type must be weekly or monthly")
+		return
+	}
+
+	at := time.Now()
+	if date := c.Query("date"); date != "" {
+		at, err = time.Parse("2006-01-02", date)
+		if err != nil {
+			common.SendError(c, http.StatusBadRequest, "Invalid date, expected YYYY-MM-DD")
+			return
+		}
+	}
+
+	report, err := rc.ReportService.GenerateReport(c.Request.Context(), userID, periodType, at)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, report, "Report generated successfully")
+}
+
+// GetBudgetStatus godoc
+// @Summary Get budget status
+// @Description Get spending against every active budget in its current period. Only the split lines booked to the budget category are counted.
+// @Tags Reports
+// @Produce json
+// @Success 200 {object} common.Response{data=[]response.BudgetStatusResponse} "Budget status retrieved successfully"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /budgets/status [get]
+func (rc *ReportController) GetBudgetStatus(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	status, err := rc.BudgetService.GetBudgetStatus(c.Request.Context(), userID, time.Now())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, status, "Budget status retrieved successfully")
+}
+
+// EvaluateBudgets godoc
+// @Summary Request a budget evaluation
+// @Description Store a budget_evaluation AI analysis whose input is the status of every active budget and savings goal. The output is filled in once the analysis has run.
+// @Tags Reports
+// @Produce json
+// @Success 201 {object} common.Response{data=response.BudgetEvaluationResponse} "Budget evaluation requested"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /budgets/evaluation [post]
+func (rc *ReportController) EvaluateBudgets(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	evaluation, err := rc.BudgetEvaluationService.Evaluate(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusCreated, evaluation, "Budget evaluation requested")
+}
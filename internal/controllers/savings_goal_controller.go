@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	"gin-backend-app/internal/dto/common"
+	"gin-backend-app/internal/dto/request"
+	"gin-backend-app/internal/services"
+	"gin-backend-app/internal/validation"
+	"gin-backend-app/pkg/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SavingsGoalController struct {
+	SavingsGoalService *services.SavingsGoalService
+}
+
+func NewSavingsGoalController(savingsGoalService *services.SavingsGoalService) *SavingsGoalController {
+	return &SavingsGoalController{
+		SavingsGoalService: savingsGoalService,
+	}
+}
+
+// ListGoals godoc
+// @Summary List savings goals
+// @Description Get all savings goals of the authenticated user with contribution progress, required monthly contribution and projected completion date
+// @Tags Savings Goals
+// @Produce json
+// @Success 200 {object} common.Response{data=[]response.SavingsGoalResponse} "Savings goals retrieved successfully"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /goals [get]
+func (gc *SavingsGoalController) ListGoals(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	goals, err := gc.SavingsGoalService.ListGoals(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, goals, "Savings goals retrieved successfully")
+}
+
+// GetGoal godoc
+// @Summary Get savings goal
+// @Description Get a single savings goal with its progress and projections
+// @Tags Savings Goals
+// @Produce json
+// @Param id path string true "Savings goal ID"
+// @Success 200 {object} common.Response{data=response.SavingsGoalResponse} "Savings goal retrieved successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid goal ID"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Failure 404 {object} common.ErrorResponse "Savings goal not found"
+// @Security BearerAuth
+// @Router /goals/{id} [get]
+func (gc *SavingsGoalController) GetGoal(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	goalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.SendError(c, http.StatusBadRequest, "Invalid goal ID")
+		return
+	}
+
+	goal, err := gc.SavingsGoalService.GetGoal(c.Request.Context(), userID, goalID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, goal, "Savings goal retrieved successfully")
+}
+
+// CreateGoal godoc
+// @Summary Create savings goal
+// @Description Create a savings goal linked to a category. Transactions booked to that category count as contributions.
+// @Tags Savings Goals
+// @Accept json
+// @Produce json
+// @Param request body request.CreateSavingsGoalRequest true "Savings goal data"
+// @Success 201 {object} common.Response{data=response.SavingsGoalResponse} "Savings goal created successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid request data"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /goals [post]
+func (gc *SavingsGoalController) CreateGoal(c *gin.Context) {
+	var req request.CreateSavingsGoalRequest
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.BindError(c, err))
+		return
+	}
+
+	goal, err := gc.SavingsGoalService.CreateGoal(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusCreated, goal, "Savings goal created successfully")
+}
+
+// UpdateGoal godoc
+// @Summary Update savings goal
+// @Description Update target, deadline, linked category or description of a savings goal
+// @Tags Savings Goals
+// @Accept json
+// @Produce json
+// @Param id path string true "Savings goal ID"
+// @Param request body request.UpdateSavingsGoalRequest true "Savings goal data"
+// @Success 200 {object} common.Response{data=response.SavingsGoalResponse} "Savings goal updated successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid request data"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Failure 404 {object} common.ErrorResponse "Savings goal not found"
+// @Security BearerAuth
+// @Router /goals/{id} [put]
+func (gc *SavingsGoalController) UpdateGoal(c *gin.Context) {
+	var req request.UpdateSavingsGoalRequest
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	goalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.SendError(c, http.StatusBadRequest, "Invalid goal ID")
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.BindError(c, err))
+		return
+	}
+
+	goal, err := gc.SavingsGoalService.UpdateGoal(c.Request.Context(), userID, goalID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, goal, "Savings goal updated successfully")
+}
+
+// DeleteGoal godoc
+// @Summary Delete savings goal
+// @Description Delete a savings goal. Linked transactions are not affected.
+// @Tags Savings Goals
+// @Produce json
+// @Param id path string true "Savings goal ID"
+// @Success 200 {object} common.Response "Savings goal deleted successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid goal ID"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Failure 404 {object} common.ErrorResponse "Savings goal not found"
+// @Security BearerAuth
+// @Router /goals/{id} [delete]
+func (gc *SavingsGoalController) DeleteGoal(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	goalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.SendError(c, http.StatusBadRequest, "Invalid goal ID")
+		return
+	}
+
+	if err := gc.SavingsGoalService.DeleteGoal(c.Request.Context(), userID, goalID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, gin.H{
+		"id": goalID,
+	}, "Savings goal deleted successfully")
+}
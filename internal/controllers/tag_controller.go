@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"gin-backend-app/internal/dto/common"
+	"gin-backend-app/internal/dto/request"
+	"gin-backend-app/internal/services"
+	"gin-backend-app/internal/validation"
+	"gin-backend-app/pkg/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TagController struct {
+	TagService *services.TagService
+}
+
+func NewTagController(tagService *services.TagService) *TagController {
+	return &TagController{
+		TagService: tagService,
+	}
+}
+
+// ListTags godoc
+// @Summary List tags
+// @Description Get all tags of the authenticated user
+// @Tags Tags
+// @Produce json
+// @Success 200 {object} common.Response{data=[]response.TagResponse} "Tags retrieved successfully"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /tags [get]
+func (tc *TagController) ListTags(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	tags, err := tc.TagService.ListTags(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, tags, "Tags retrieved successfully")
+}
+
+// CreateTag godoc
+// @Summary Create tag
+// @Description Create a tag. Names are case-insensitive and stored lowercased. Tags are also created on the fly when used on a transaction.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param request body request.TagRequest true "Tag data"
+// @Success 201 {object} common.Response{data=response.TagResponse} "Tag created successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid request data or tag already exists"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /tags [post]
+func (tc *TagController) CreateTag(c *gin.Context) {
+	var req request.TagRequest
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.BindError(c, err))
+		return
+	}
+
+	tag, err := tc.TagService.CreateTag(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusCreated, tag, "Tag created successfully")
+}
+
+// UpdateTag godoc
+// @Summary Update tag
+// @Description Rename a tag or change its color
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Param request body request.TagRequest true "Tag data"
+// @Success 200 {object} common.Response{data=response.TagResponse} "Tag updated successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid request data or tag already exists"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Failure 404 {object} common.ErrorResponse "Tag not found"
+// @Security BearerAuth
+// @Router /tags/{id} [put]
+func (tc *TagController) UpdateTag(c *gin.Context) {
+	var req request.TagRequest
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	tagID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.SendError(c, http.StatusBadRequest, "Invalid tag ID")
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.BindError(c, err))
+		return
+	}
+
+	tag, err := tc.TagService.UpdateTag(c.Request.Context(), userID, tagID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, tag, "Tag updated successfully")
+}
+
+// DeleteTag godoc
+// @Summary Delete tag
+// @Description Delete a tag and detach it from all transactions
+// @Tags Tags
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Success 200 {object} common.Response "Tag deleted successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid tag ID"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Failure 404 {object} common.ErrorResponse "Tag not found"
+// @Security BearerAuth
+// @Router /tags/{id} [delete]
+func (tc *TagController) DeleteTag(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	tagID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.SendError(c, http.StatusBadRequest, "Invalid tag ID")
+		return
+	}
+
+	if err := tc.TagService.DeleteTag(c.Request.Context(), userID, tagID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, gin.H{
+		"id": tagID,
+	}, "Tag deleted successfully")
+}
+
+// GetTagSpending godoc
+// @Summary Get spending per tag
+// @Description Aggregate income and expense of tagged transactions per tag within a date range
+// @Tags Tags
+// @Produce json
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date (YYYY-MM-DD)"
+// @Success 200 {object} common.Response{data=[]response.TagSpendingResponse} "Tag spending retrieved successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /tags/spending [get]
+func (tc *TagController) GetTagSpending(c *gin.Context) {
+	var query request.TagSpendingQuery
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(validation.BindError(c, err))
+		return
+	}
+
+	spending, err := tc.TagService.GetTagSpending(c.Request.Context(), userID, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, spending, "Tag spending retrieved successfully")
+}
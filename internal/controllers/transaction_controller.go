@@ -0,0 +1,299 @@
+package controllers
+
+import (
+	"bytes"
+	"gin-backend-app/internal/dto/common"
+	"gin-backend-app/internal/dto/request"
+	"gin-backend-app/internal/services"
+	"gin-backend-app/internal/validation"
+	"gin-backend-app/pkg/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const maxImportFileSize = 5 << 20
+
+type TransactionController struct {
+	TransactionService *services.TransactionService
+}
+
+func NewTransactionController(transactionService *services.TransactionService) *TransactionController {
+	return &TransactionController{
+		TransactionService: transactionService,
+	}
+}
+
+// ListTransactions godoc
+// @Summary List transactions
+// @Description Get paginated transactions of the authenticated user. Filtering by category also matches split lines booked to that category.
+// @Tags Transactions
+// @Produce json
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param category_id query string false "Category ID"
+// @Param type query string false "income or expense"
+// @Param tags query string false "Comma separated tag names, all must match"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Page size" default(20)
+// @Success 200 {object} common.Response{data=response.TransactionListResponse} "Transactions retrieved successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /transactions [get]
+func (tc *TransactionController) ListTransactions(c *gin.Context) {
+	var query request.ListTransactionsQuery
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(validation.BindError(c, err))
+		return
+	}
+
+	transactions, err := tc.TransactionService.ListTransactions(c.Request.Context(), userID, query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, transactions, "Transactions retrieved successfully")
+}
+
+// GetTransaction godoc
+// @Summary Get transaction
+// @Description Get a single transaction with its split lines
+// @Tags Transactions
+// @Produce json
+// @Param id path string true "Transaction ID"
+// @Success 200 {object} common.Response{data=response.TransactionResponse} "Transaction retrieved successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid transaction ID"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Failure 404 {object} common.ErrorResponse "Transaction not found"
+// @Security BearerAuth
+// @Router /transactions/{id} [get]
+func (tc *TransactionController) GetTransaction(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.SendError(c, http.StatusBadRequest, "Invalid transaction ID")
+		return
+	}
+
+	transaction, err := tc.TransactionService.GetTransaction(c.Request.Context(), userID, transactionID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, transaction, "Transaction retrieved successfully")
+}
+
+// CreateTransaction godoc
+// @Summary Create transaction
+// @Description Create a transaction booked to a single category, or split across several categories. Split amounts must sum to the transaction amount.
+// @Tags Transactions
+// @Accept json
+// @Produce json
+// @Param request body request.TransactionRequest true "Transaction data"
+// @Success 201 {object} common.Response{data=response.TransactionResponse} "Transaction created successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid request data"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /transactions [post]
+func (tc *TransactionController) CreateTransaction(c *gin.Context) {
+	var req request.TransactionRequest
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.BindError(c, err))
+		return
+	}
+
+	transaction, err := tc.TransactionService.CreateTransaction(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusCreated, transaction, "Transaction created successfully")
+}
+
+// UpdateTransaction godoc
+// @Summary Update transaction
+// @Description Replace a transaction and its split lines. Sending no splits turns a split transaction back into a single-category one.
+// @Tags Transactions
+// @Accept json
+// @Produce json
+// @Param id path string true "Transaction ID"
+// @Param request body request.TransactionRequest true "Transaction data"
+// @Success 200 {object} common.Response{data=response.TransactionResponse} "Transaction updated successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid request data"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Failure 404 {object} common.ErrorResponse "Transaction not found"
+// @Security BearerAuth
+// @Router /transactions/{id} [put]
+func (tc *TransactionController) UpdateTransaction(c *gin.Context) {
+	var req request.TransactionRequest
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.SendError(c, http.StatusBadRequest, "Invalid transaction ID")
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.BindError(c, err))
+		return
+	}
+
+	transaction, err := tc.TransactionService.UpdateTransaction(c.Request.Context(), userID, transactionID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, transaction, "Transaction updated successfully")
+}
+
+// DeleteTransaction godoc
+// @Summary Delete transaction
+// @Description Delete a transaction together with its split lines
+// @Tags Transactions
+// @Produce json
+// @Param id path string true "Transaction ID"
+// @Success 200 {object} common.Response "Transaction deleted successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid transaction ID"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Failure 404 {object} common.ErrorResponse "Transaction not found"
+// @Security BearerAuth
+// @Router /transactions/{id} [delete]
+func (tc *TransactionController) DeleteTransaction(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		common.SendError(c, http.StatusBadRequest, "Invalid transaction ID")
+		return
+	}
+
+	if err := tc.TransactionService.DeleteTransaction(c.Request.Context(), userID, transactionID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, gin.H{
+		"id": transactionID,
+	}, "Transaction deleted successfully")
+}
+
+// ImportTransactions godoc
+// @Summary Import transactions from CSV
+// @Description Import transactions from a CSV file with header date,type,amount,category,description,splits. Categories are matched by name; splits are written as "Groceries=150000;Household=50000". Invalid rows are skipped and reported.
+// @Tags Transactions
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV file (max 5MB)"
+// @Success 200 {object} common.Response{data=response.TransactionImportResponse} "Transactions imported"
+// @Failure 400 {object} common.ErrorResponse "Invalid file"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /transactions/import [post]
+func (tc *TransactionController) ImportTransactions(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		common.SendError(c, http.StatusBadRequest, "CSV file is required")
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		common.SendError(c, http.StatusBadRequest, "CSV file must not exceed 5MB")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		common.SendError(c, http.StatusBadRequest, "Failed to read CSV file")
+		return
+	}
+	defer file.Close()
+
+	result, err := tc.TransactionService.ImportTransactions(c.Request.Context(), userID, file)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, result, "Transactions imported")
+}
+
+// ExportTransactions godoc
+// @Summary Export transactions as CSV
+// @Description Download transactions matching the filters as CSV in the same format accepted by the import endpoint
+// @Tags Transactions
+// @Produce text/csv
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD)"
+// @Param category_id query string false "Category ID"
+// @Param type query string false "income or expense"
+// @Param tags query string false "Comma separated tag names, all must match"
+// @Success 200 {file} file "CSV file"
+// @Failure 400 {object} common.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /transactions/export [get]
+func (tc *TransactionController) ExportTransactions(c *gin.Context) {
+	var query request.ListTransactionsQuery
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(validation.BindError(c, err))
+		return
+	}
+
+	// Render into memory first so a failing query still produces a JSON error
+	// instead of a truncated download.
+	var buf bytes.Buffer
+	if err := tc.TransactionService.ExportTransactions(c.Request.Context(), userID, query, &buf); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="transactions.csv"`)
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
@@ -4,6 +4,8 @@ import (
 	"gin-backend-app/internal/dto/common"
 	"gin-backend-app/internal/dto/request"
 	"gin-backend-app/internal/services"
+	"gin-backend-app/internal/validation"
+	"gin-backend-app/pkg/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -34,13 +36,13 @@ func (uc *UserController) LoginUser(c *gin.Context) {
 	var req request.LoginUserRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		common.SendError(c, http.StatusBadRequest, "Invalid Request Data")
+		c.Error(validation.BindError(c, err))
 		return
 	}
 
-	loginResponse, err := uc.UserService.LoginUser(req)
+	loginResponse, err := uc.UserService.LoginUser(c.Request.Context(), req)
 	if err != nil {
-		common.SendError(c, http.StatusUnauthorized, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -55,19 +57,20 @@ func (uc *UserController) LoginUser(c *gin.Context) {
 // @Produce json
 // @Param request body request.CreateUserRequest true "User registration data"
 // @Success 201 {object} common.Response "User created successfully"
-// @Failure 400 {object} common.ErrorResponse "Invalid request data or user already exists"
+// @Failure 400 {object} common.ErrorResponse "Invalid request data or password rejected by the policy"
+// @Failure 409 {object} common.ErrorResponse "Email or username already exists"
 // @Router /auth/register [post]
 func (uc *UserController) RegisterUser(c *gin.Context) {
 	var req request.CreateUserRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		common.SendError(c, http.StatusBadRequest, "Invalid Request Data")
+		c.Error(validation.BindError(c, err))
 		return
 	}
 
-	registerResponse, err := uc.UserService.CreateUser(req)
+	registerResponse, err := uc.UserService.CreateUser(c.Request.Context(), req)
 	if err != nil {
-		common.SendError(c, http.StatusBadRequest, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -84,18 +87,19 @@ func (uc *UserController) RegisterUser(c *gin.Context) {
 // @Success 200 {object} common.Response "Password reset email sent successfully"
 // @Failure 400 {object} common.ErrorResponse "Invalid request data"
 // @Failure 404 {object} common.ErrorResponse "Email not found"
+// @Failure 503 {object} common.ErrorResponse "Email service is not configured"
 // @Router /auth/request-change-password [post]
 func (uc *UserController) SendEmailPasswordReset(c *gin.Context) {
 	var req request.RequestChangePasswordOtpRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		common.SendError(c, http.StatusBadRequest, "Invalid Request Data")
+		c.Error(validation.BindError(c, err))
 		return
 	}
 
-	err := uc.UserService.SendOtpToResetPassword(req.Email)
+	err := uc.UserService.SendOtpToResetPassword(c.Request.Context(), req.Email)
 	if err != nil {
-		common.SendError(c, http.StatusNotFound, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -114,19 +118,19 @@ func (uc *UserController) SendEmailPasswordReset(c *gin.Context) {
 // @Param request body request.VerifyOTPAndEmailRequest true "Email and OTP verification data"
 // @Success 200 {object} common.Response "OTP verified successfully"
 // @Failure 400 {object} common.ErrorResponse "Invalid request data or OTP"
-// @Failure 404 {object} common.ErrorResponse "Email not found or OTP expired"
+// @Failure 404 {object} common.ErrorResponse "Email not found"
 // @Router /auth/verify-otp-password-change [post]
 func (uc *UserController) GenerateAndSetVerificationToken(c *gin.Context) {
 	var req request.VerifyOTPAndEmailRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		common.SendError(c, http.StatusBadRequest, "Invalid Request Data")
+		c.Error(validation.BindError(c, err))
 		return
 	}
 
-	verificationToken, err := uc.UserService.GenerateAndSetVerificationToken(req.Email, req.TokenOtp)
+	verificationToken, err := uc.UserService.GenerateAndSetVerificationToken(c.Request.Context(), req.Email, req.TokenOtp)
 	if err != nil {
-		common.SendError(c, http.StatusBadRequest, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -145,14 +149,14 @@ func (uc *UserController) GenerateAndSetVerificationToken(c *gin.Context) {
 // @Param token query string true "Verification token from OTP verification"
 // @Param request body request.ChangePasswordRequest true "New password data"
 // @Success 200 {object} common.Response "Password changed successfully"
-// @Failure 400 {object} common.ErrorResponse "Invalid request data or token"
-// @Failure 404 {object} common.ErrorResponse "Invalid or expired verification token"
+// @Failure 400 {object} common.ErrorResponse "Invalid request data or password rejected by the policy"
+// @Failure 401 {object} common.ErrorResponse "Invalid or expired verification token"
 // @Router /auth/change-password [post]
 func (uc *UserController) ValidateAndChangePassword(c *gin.Context) {
 	var req request.ChangePasswordRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		common.SendError(c, http.StatusBadRequest, "Invalid Request Data")
+		c.Error(validation.BindError(c, err))
 		return
 	}
 
@@ -162,9 +166,9 @@ func (uc *UserController) ValidateAndChangePassword(c *gin.Context) {
 		return
 	}
 
-	err := uc.UserService.ValidateAndChangePassword(verificationToken, req.NewPassword, req.ConfirmPassword)
+	err := uc.UserService.ValidateAndChangePassword(c.Request.Context(), verificationToken, req.NewPassword, req.ConfirmPassword)
 	if err != nil {
-		common.SendError(c, http.StatusBadRequest, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -173,3 +177,99 @@ func (uc *UserController) ValidateAndChangePassword(c *gin.Context) {
 	}, "Password change successful")
 }
 
+
+// GetPreferences godoc
+// @Summary Get user preferences
+// @Description Get the email locale and digest subscriptions of the authenticated user
+// @Tags Users
+// @Produce json
+// @Success 200 {object} common.Response{data=response.UserPreferencesResponse} "Preferences retrieved successfully"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /users/me/preferences [get]
+func (uc *UserController) GetPreferences(c *gin.Context) {
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	preferences, err := uc.UserService.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, preferences, "Preferences retrieved successfully")
+}
+
+// UpdatePreferences godoc
+// @Summary Update user preferences
+// @Description Change the email locale and opt in or out of the weekly and monthly digest emails. Omitted fields are left unchanged.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body request.UpdatePreferencesRequest true "Preferences"
+// @Success 200 {object} common.Response{data=response.UserPreferencesResponse} "Preferences updated successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid request data"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /users/me/preferences [put]
+func (uc *UserController) UpdatePreferences(c *gin.Context) {
+	var req request.UpdatePreferencesRequest
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.BindError(c, err))
+		return
+	}
+
+	preferences, err := uc.UserService.UpdatePreferences(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, preferences, "Preferences updated successfully")
+}
+
+// UpdatePassword godoc
+// @Summary Change password
+// @Description Change the password of the authenticated user. The current password is required and the new one must satisfy the password policy.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body request.UpdatePasswordRequest true "Current and new password"
+// @Success 200 {object} common.Response "Password changed successfully"
+// @Failure 400 {object} common.ErrorResponse "Invalid request data, wrong current password, or password rejected by the policy"
+// @Failure 401 {object} common.ErrorResponse "Authentication required"
+// @Security BearerAuth
+// @Router /users/me/password [put]
+func (uc *UserController) UpdatePassword(c *gin.Context) {
+	var req request.UpdatePasswordRequest
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		common.SendError(c, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(validation.BindError(c, err))
+		return
+	}
+
+	if err := uc.UserService.ChangePassword(c.Request.Context(), userID, req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	common.SendResponse(c, http.StatusOK, gin.H{
+		"message": "Password changed successfully",
+	}, "Password change successful")
+}
@@ -0,0 +1,36 @@
+package cron
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"gin-backend-app/internal/models"
+)
+
+func (s *Scheduler) DigestJobs() {
+	// tiap Senin jam 06:00, ringkasan minggu sebelumnya
+	s.addDigestJob("0 0 6 * * 1", models.PeriodWeekly)
+	// tiap tanggal 1 jam 06:30, ringkasan bulan sebelumnya
+	s.addDigestJob("0 30 6 1 * *", models.PeriodMonthly)
+}
+
+func (s *Scheduler) addDigestJob(spec string, periodType models.PeriodType) {
+	_, err := s.cron.AddFunc(spec, func() {
+		s.observe("digest_"+string(periodType), func(ctx context.Context) error {
+			// Yesterday lies in the period that has just ended.
+			sent, failed, err := s.DigestService.SendDigests(ctx, periodType, time.Now().AddDate(0, 0, -1))
+			if err != nil {
+				slog.ErrorContext(ctx, "digest job failed", "period", periodType, "error", err)
+				return err
+			}
+			slog.InfoContext(ctx, "digest job finished", "period", periodType, "queued", sent, "failed", failed)
+			return nil
+		})
+	})
+	if err != nil {
+		slog.Error("gagal daftar digest job", "period", periodType, "error", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,55 @@
+package cron
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// sentRetention is how long delivered outbox messages are kept for auditing.
+const sentRetention = 7 * 24 * time.Hour
+
+func (s *Scheduler) EmailOutboxJobs() {
+	// tiap 5 detik, skip kalau batch sebelumnya belum selesai
+	var running atomic.Bool
+	_, err := s.cron.AddFunc("*/5 * * * * *", func() {
+		if !running.CompareAndSwap(false, true) {
+			return
+		}
+		defer running.Store(false)
+
+		s.observe("email_outbox", func(ctx context.Context) error {
+			sent, failed, err := s.EmailOutboxService.ProcessDue(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "email outbox job failed", "error", err)
+				return err
+			}
+			if sent > 0 || failed > 0 {
+				slog.InfoContext(ctx, "email outbox job finished", "sent", sent, "failed", failed)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		slog.Error("gagal daftar email outbox job", "error", err)
+		os.Exit(1)
+	}
+
+	// tiap hari jam 03:00
+	_, err = s.cron.AddFunc("0 0 3 * * *", func() {
+		s.observe("email_outbox_cleanup", func(ctx context.Context) error {
+			if err := s.EmailOutboxService.CleanupSent(ctx, sentRetention); err != nil {
+				slog.ErrorContext(ctx, "email outbox cleanup job failed", "error", err)
+				return err
+			}
+			slog.InfoContext(ctx, "email outbox cleanup job finished")
+			return nil
+		})
+	})
+	if err != nil {
+		slog.Error("gagal daftar email outbox cleanup job", "error", err)
+		os.Exit(1)
+	}
+}
@@ -1,24 +1,42 @@
 package cron
 
 import (
-	"log"
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
 
+	"gin-backend-app/internal/metrics"
 	"gin-backend-app/internal/services"
+	"gin-backend-app/internal/tracing"
 
 	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel/codes"
 )
 
 type Scheduler struct {
 	cron      *cron.Cron
+	// ctx is passed to every job and cancelled by Cancel, so queries and
+	// sends of jobs still running at shutdown are aborted.
+	ctx       context.Context
+	cancel    context.CancelFunc
+	running   atomic.Bool
 	EmailVerificationService  *services.EmailVerficationService
+	EmailOutboxService *services.EmailOutboxService
+	DigestService *services.DigestService
 }
 
-func NewScheduler(emailVerificationService *services.EmailVerficationService) *Scheduler {
+func NewScheduler(emailVerificationService *services.EmailVerficationService, emailOutboxService *services.EmailOutboxService, digestService *services.DigestService) *Scheduler {
 	c := cron.New(cron.WithSeconds()) 
+	ctx, cancel := context.WithCancel(context.Background())
 
 	s := &Scheduler{
 		cron:     c,
+		ctx:      ctx,
+		cancel:   cancel,
 		EmailVerificationService: emailVerificationService,
+		EmailOutboxService: emailOutboxService,
+		DigestService: digestService,
 	}
 
 	s.registerJobs()
@@ -28,14 +46,45 @@ func NewScheduler(emailVerificationService *services.EmailVerficationService) *S
 
 func (s *Scheduler) registerJobs() {
 	s.CleanTokenJobs()
+	s.EmailOutboxJobs()
+	s.DigestJobs()
 }
 
 func (s *Scheduler) Start() {
-	log.Println("[CRON] start scheduler")
+	slog.Info("cron scheduler started")
 	s.cron.Start()
+	s.running.Store(true)
 }
 
-func (s *Scheduler) Stop() {
-	log.Println("[CRON] stop scheduler")
-	s.cron.Stop()
-}
\ No newline at end of file
+// Stop prevents new runs and returns a context that is done once the jobs
+// already running have finished.
+func (s *Scheduler) Stop() context.Context {
+	slog.Info("cron scheduler stopping")
+	s.running.Store(false)
+	return s.cron.Stop()
+}
+
+// Running reports whether the scheduler has been started and not stopped.
+func (s *Scheduler) Running() bool {
+	return s.running.Load()
+}
+
+// Cancel aborts the jobs that are still running after Stop.
+func (s *Scheduler) Cancel() {
+	s.cancel()
+}
+
+// observe runs one job inside its own trace span and records its duration
+// and outcome.
+func (s *Scheduler) observe(job string, run func(ctx context.Context) error) {
+	ctx, span := tracing.Tracer().Start(s.ctx, "cron "+job)
+	defer span.End()
+
+	start := time.Now()
+	err := run(ctx)
+	metrics.ObserveCronJob(job, time.Since(start), err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
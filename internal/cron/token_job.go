@@ -1,21 +1,27 @@
 package cron
 
 import (
-	"log"
+	"context"
+	"log/slog"
+	"os"
 )
 
 func (s *Scheduler) CleanTokenJobs() {
 	// tiap hari jam 02:00
 	spec := "0 0 2 * * *"
 	_, err := s.cron.AddFunc(spec, func() {
-		if err := s.EmailVerificationService.CleanupExpiredTokens(); err != nil {
-			log.Printf("[CRON] token cleanup error: %v\n", err)
-			return
-		}
-		log.Println("[CRON] token cleanup success")
+		s.observe("token_cleanup", func(ctx context.Context) error {
+			if err := s.EmailVerificationService.CleanupExpiredTokens(ctx); err != nil {
+				slog.ErrorContext(ctx, "token cleanup job failed", "error", err)
+				return err
+			}
+			slog.InfoContext(ctx, "token cleanup job finished")
+			return nil
+		})
 	})
 
 	if err != nil {
-		log.Fatalf("[CRON] gagal daftar token job: %v", err)
+		slog.Error("gagal daftar token job", "error", err)
+		os.Exit(1)
 	}
 }
\ No newline at end of file
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS user_tokens;
+DROP TABLE IF EXISTS ai_logs;
+DROP TABLE IF EXISTS period_reports;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS user_budgets;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS users;
+
+DROP TYPE IF EXISTS ai_analysis_type_enum;
+DROP TYPE IF EXISTS period_type_enum;
+DROP TYPE IF EXISTS transaction_group_enum;
@@ -0,0 +1,163 @@
+-- Baseline schema: exactly what AutoMigrate and addFinanceIndexes produced
+-- before versioned migrations. Every statement is idempotent so the migration
+-- can be recorded on databases created by AutoMigrate; later changes live in
+-- their own migrations so they are applied to those databases too.
+
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'transaction_group_enum') THEN
+		CREATE TYPE transaction_group_enum AS ENUM ('income', 'expense');
+	END IF;
+	IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'period_type_enum') THEN
+		CREATE TYPE period_type_enum AS ENUM ('weekly', 'monthly');
+	END IF;
+	IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'ai_analysis_type_enum') THEN
+		CREATE TYPE ai_analysis_type_enum AS ENUM (
+			'weekly_summary',
+			'monthly_summary',
+			'yearly_summary',
+			'compare_period',
+			'budget_evaluation'
+		);
+	END IF;
+END$$;
+
+-- users
+CREATE TABLE IF NOT EXISTS users (
+	id uuid DEFAULT gen_random_uuid(),
+	name varchar(100) NOT NULL,
+	email varchar(100) NOT NULL,
+	password varchar(255) NOT NULL,
+	is_email_verified boolean NOT NULL DEFAULT false,
+	email_verified_at timestamptz,
+	created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	updated_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (id),
+	CONSTRAINT uni_users_email UNIQUE (email)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_unique ON users (LOWER(email));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_name_unique ON users (LOWER(name));
+
+-- categories
+CREATE TABLE IF NOT EXISTS categories (
+	id uuid DEFAULT gen_random_uuid(),
+	user_id uuid NOT NULL,
+	name varchar(100) NOT NULL,
+	group_type transaction_group_enum NOT NULL,
+	color varchar(7) DEFAULT '#000000',
+	icon varchar(50),
+	description text,
+	is_default boolean DEFAULT false,
+	created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	updated_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_categories_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_categories_user_id ON categories (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_user_name_type ON categories (user_id, LOWER(name), group_type);
+CREATE INDEX IF NOT EXISTS idx_categories_user_group_type ON categories (user_id, group_type);
+
+-- user_budgets
+CREATE TABLE IF NOT EXISTS user_budgets (
+	id uuid DEFAULT gen_random_uuid(),
+	user_id uuid NOT NULL,
+	category_id uuid NOT NULL,
+	amount decimal(15,2) NOT NULL,
+	period_type period_type_enum NOT NULL,
+	period_value bigint NOT NULL,
+	start_date date NOT NULL,
+	end_date date,
+	is_active boolean DEFAULT true,
+	created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	updated_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_user_budgets_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+	CONSTRAINT fk_user_budgets_category FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_budgets_user_id ON user_budgets (user_id);
+CREATE INDEX IF NOT EXISTS idx_user_budgets_category_id ON user_budgets (category_id);
+
+-- transactions
+CREATE TABLE IF NOT EXISTS transactions (
+	id uuid DEFAULT gen_random_uuid(),
+	user_id uuid NOT NULL,
+	category_id uuid NOT NULL,
+	type transaction_group_enum NOT NULL,
+	amount decimal(15,2) NOT NULL,
+	description text,
+	date date NOT NULL,
+	created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	updated_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_transactions_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+	CONSTRAINT fk_transactions_category FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_transactions_user_id ON transactions (user_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_category_id ON transactions (category_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_date ON transactions (date);
+CREATE INDEX IF NOT EXISTS idx_transactions_user_date ON transactions (user_id, date DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_user_category_date ON transactions (user_id, category_id, date DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_user_type_date ON transactions (user_id, type, date DESC);
+
+-- period_reports
+CREATE TABLE IF NOT EXISTS period_reports (
+	id uuid DEFAULT gen_random_uuid(),
+	user_id uuid NOT NULL,
+	period_type period_type_enum NOT NULL,
+	period_value bigint NOT NULL,
+	period_start date NOT NULL,
+	period_end date NOT NULL,
+	total_income decimal(15,2) DEFAULT 0,
+	total_expense decimal(15,2) DEFAULT 0,
+	net_flow decimal(15,2) DEFAULT 0,
+	report_data jsonb,
+	generated_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	updated_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_period_reports_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_period_reports_user_id ON period_reports (user_id);
+CREATE INDEX IF NOT EXISTS idx_period_reports_user_period ON period_reports (user_id, period_start, period_end);
+CREATE INDEX IF NOT EXISTS idx_period_reports_user_type ON period_reports (user_id, period_type);
+
+-- ai_logs
+CREATE TABLE IF NOT EXISTS ai_logs (
+	id uuid DEFAULT gen_random_uuid(),
+	user_id uuid NOT NULL,
+	transaction_id uuid,
+	category_id uuid,
+	analysis_type ai_analysis_type_enum NOT NULL,
+	input_data jsonb,
+	output_data jsonb,
+	created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_ai_logs_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+	CONSTRAINT fk_ai_logs_transaction FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE SET NULL,
+	CONSTRAINT fk_ai_logs_category FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_ai_logs_user_id ON ai_logs (user_id);
+CREATE INDEX IF NOT EXISTS idx_ai_logs_transaction_id ON ai_logs (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_ai_logs_category_id ON ai_logs (category_id);
+CREATE INDEX IF NOT EXISTS idx_ai_logs_user_created_at ON ai_logs (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_ai_logs_user_analysis_type ON ai_logs (user_id, analysis_type, created_at DESC);
+
+-- user_tokens
+CREATE TABLE IF NOT EXISTS user_tokens (
+	id uuid DEFAULT gen_random_uuid(),
+	user_id uuid NOT NULL,
+	token_otp char(64) NOT NULL,
+	verify_token char(64),
+	token_type varchar(50) NOT NULL,
+	expires_at timestamptz NOT NULL,
+	used_at timestamptz,
+	created_at timestamptz,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_user_tokens_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_user_tokens_user_id ON user_tokens (user_id);
+CREATE INDEX IF NOT EXISTS idx_user_tokens_token_type ON user_tokens (token_type);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_tokens_token_otp ON user_tokens (token_otp);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_tokens_verify_token ON user_tokens (verify_token);
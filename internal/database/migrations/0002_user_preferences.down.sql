@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS digest_monthly;
+ALTER TABLE users DROP COLUMN IF EXISTS digest_weekly;
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
@@ -0,0 +1,4 @@
+-- Email language and digest subscriptions, added after the baseline.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale varchar(10) NOT NULL DEFAULT 'en';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS digest_weekly boolean NOT NULL DEFAULT false;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS digest_monthly boolean NOT NULL DEFAULT false;
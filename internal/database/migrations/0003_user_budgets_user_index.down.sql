@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_user_budgets_user_id;
+CREATE UNIQUE INDEX idx_user_budgets_user_id ON user_budgets (user_id);
@@ -0,0 +1,4 @@
+-- The baseline index allowed a single budget per user. Budgets are per
+-- category, so the user index only serves lookups.
+DROP INDEX IF EXISTS idx_user_budgets_user_id;
+CREATE INDEX idx_user_budgets_user_id ON user_budgets (user_id);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS digest_deliveries;
+DROP TABLE IF EXISTS budget_alerts;
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS email_outbox;
+DROP TABLE IF EXISTS attachments;
+DROP TABLE IF EXISTS transaction_tags;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS transaction_splits;
+DROP TABLE IF EXISTS savings_goals;
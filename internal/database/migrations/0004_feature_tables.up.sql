@@ -0,0 +1,132 @@
+-- Tables added after the baseline. Databases upgraded with AutoMigrate may
+-- already have some of them, so every statement is idempotent.
+
+-- savings_goals
+CREATE TABLE IF NOT EXISTS savings_goals (
+	id uuid DEFAULT gen_random_uuid(),
+	user_id uuid NOT NULL,
+	category_id uuid,
+	name varchar(100) NOT NULL,
+	target_amount decimal(15,2) NOT NULL,
+	start_date date NOT NULL,
+	deadline date NOT NULL,
+	description text,
+	created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	updated_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_savings_goals_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+	CONSTRAINT fk_savings_goals_category FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_savings_goals_user_id ON savings_goals (user_id);
+CREATE INDEX IF NOT EXISTS idx_savings_goals_category_id ON savings_goals (category_id);
+CREATE INDEX IF NOT EXISTS idx_savings_goals_user_deadline ON savings_goals (user_id, deadline);
+
+-- transaction_splits
+CREATE TABLE IF NOT EXISTS transaction_splits (
+	id uuid DEFAULT gen_random_uuid(),
+	transaction_id uuid NOT NULL,
+	category_id uuid NOT NULL,
+	amount decimal(15,2) NOT NULL,
+	description text,
+	created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_transactions_splits FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE CASCADE,
+	CONSTRAINT fk_transaction_splits_category FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_transaction_splits_transaction_id ON transaction_splits (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_transaction_splits_category_id ON transaction_splits (category_id);
+CREATE INDEX IF NOT EXISTS idx_transaction_splits_category_transaction ON transaction_splits (category_id, transaction_id);
+
+-- tags & transaction_tags
+CREATE TABLE IF NOT EXISTS tags (
+	id uuid DEFAULT gen_random_uuid(),
+	user_id uuid NOT NULL,
+	name varchar(50) NOT NULL,
+	color varchar(7) DEFAULT '#000000',
+	created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	updated_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_tags_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_tags_user_id ON tags (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_user_name_unique ON tags (user_id, name);
+
+-- attachments
+CREATE TABLE IF NOT EXISTS attachments (
+	id uuid DEFAULT gen_random_uuid(),
+	user_id uuid NOT NULL,
+	transaction_id uuid NOT NULL,
+	file_name varchar(255) NOT NULL,
+	content_type varchar(100) NOT NULL,
+	size bigint NOT NULL,
+	storage_key varchar(255) NOT NULL,
+	thumbnail_key varchar(255),
+	created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_attachments_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+	CONSTRAINT fk_attachments_transaction FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_attachments_user_id ON attachments (user_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_transaction_id ON attachments (transaction_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_attachments_storage_key ON attachments (storage_key);
+
+-- email_outbox
+CREATE TABLE IF NOT EXISTS email_outbox (
+	id uuid DEFAULT gen_random_uuid(),
+	to_address varchar(255) NOT NULL,
+	subject varchar(255) NOT NULL,
+	body text NOT NULL,
+	text_body text NOT NULL DEFAULT '',
+	status varchar(20) NOT NULL DEFAULT 'pending',
+	attempts bigint NOT NULL DEFAULT 0,
+	next_attempt_at timestamptz NOT NULL,
+	locked_until timestamptz,
+	last_error text,
+	sent_at timestamptz,
+	created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	updated_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (id)
+);
+-- text_body was added after the table was first created.
+ALTER TABLE email_outbox ADD COLUMN IF NOT EXISTS text_body text NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_email_outbox_status_next_attempt ON email_outbox (status, next_attempt_at);
+
+-- notifications
+CREATE TABLE IF NOT EXISTS notifications (
+	id uuid DEFAULT gen_random_uuid(),
+	user_id uuid NOT NULL,
+	type varchar(50) NOT NULL,
+	title varchar(255) NOT NULL,
+	body text NOT NULL,
+	data jsonb,
+	read_at timestamptz,
+	created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_notifications_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created_at ON notifications (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_unread ON notifications (user_id) WHERE read_at IS NULL;
+
+-- budget_alerts: one alert per budget, period and threshold
+CREATE TABLE IF NOT EXISTS budget_alerts (
+	id uuid DEFAULT gen_random_uuid(),
+	budget_id uuid NOT NULL,
+	period_start date NOT NULL,
+	threshold bigint NOT NULL,
+	created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_budget_alerts_budget FOREIGN KEY (budget_id) REFERENCES user_budgets (id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_budget_alerts_budget_period_threshold ON budget_alerts (budget_id, period_start, threshold);
+
+-- digest_deliveries: one digest per user and period
+CREATE TABLE IF NOT EXISTS digest_deliveries (
+	id uuid DEFAULT gen_random_uuid(),
+	user_id uuid NOT NULL,
+	period_type period_type_enum NOT NULL,
+	period_start date NOT NULL,
+	created_at timestamptz DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (id),
+	CONSTRAINT fk_digest_deliveries_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_digest_deliveries_user_period ON digest_deliveries (user_id, period_type, period_start);
@@ -0,0 +1,287 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// advisoryLockKey serialises migrations across replicas starting at the
+// same time. The value is arbitrary but must never change.
+const advisoryLockKey int64 = 0x7472617370616301
+
+var (
+	ErrChecksumMismatch = errors.New("applied migration differs from embedded file")
+	ErrUnknownMigration = errors.New("applied migration is not embedded in this binary")
+	ErrNoDownMigration  = errors.New("migration has no down file")
+)
+
+// MigrationStatus describes one migration as seen by the database.
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	// Modified is set when the applied checksum differs from the embedded
+	// file; Missing when the version is applied but no longer embedded.
+	Modified bool
+	Missing  bool
+}
+
+type appliedMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+type Migrator struct {
+	DB         *sql.DB
+	Migrations []Migration
+}
+
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+	return &Migrator{DB: db, Migrations: migrations}, nil
+}
+
+// Up applies every pending migration in version order, each in its own
+// transaction, and returns the ones it applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(done); err != nil {
+			return err
+		}
+
+		for _, migration := range m.Migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			start := time.Now()
+			if err := m.run(ctx, conn, migration, migration.Up, true); err != nil {
+				return fmt.Errorf("migration %04d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			slog.InfoContext(ctx, "migration applied", "version", migration.Version, "name", migration.Name, "duration_ms", time.Since(start).Milliseconds())
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the last steps applied migrations, newest first.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, errors.New("steps must be at least 1")
+	}
+
+	var reverted []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(done); err != nil {
+			return err
+		}
+
+		for i := len(m.Migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.Migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("%04d_%s: %w", migration.Version, migration.Name, ErrNoDownMigration)
+			}
+			if err := m.run(ctx, conn, migration, migration.Down, false); err != nil {
+				return fmt.Errorf("rollback of %04d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			slog.InfoContext(ctx, "migration reverted", "version", migration.Version, "name", migration.Name)
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lists embedded migrations with their applied state, followed by any
+// applied versions this binary does not know about. It only reads: before
+// the first migration run every migration is reported as pending.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	done := map[int64]appliedMigration{}
+	exists, err := schemaMigrationsExists(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		if done, err = m.applied(ctx, conn); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.Migrations))
+	for _, migration := range m.Migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := done[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+			status.Modified = record.Checksum != migration.Checksum
+			delete(done, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	known := len(statuses)
+	for _, record := range done {
+		appliedAt := record.AppliedAt
+		statuses = append(statuses, MigrationStatus{
+			Version:   record.Version,
+			Name:      record.Name,
+			AppliedAt: &appliedAt,
+			Missing:   true,
+		})
+	}
+	missing := statuses[known:]
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].Version < missing[j].Version
+	})
+	return statuses, nil
+}
+
+// withLock runs fn on a dedicated connection holding the migration advisory
+// lock, so concurrent replicas apply migrations one at a time.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Use a fresh context so the lock is released even if ctx was cancelled.
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", advisoryLockKey); err != nil {
+			slog.Error("failed to release migration lock", "error", err)
+		}
+	}()
+
+	if err := ensureSchemaMigrations(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func ensureSchemaMigrations(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version bigint PRIMARY KEY,
+			name varchar(255) NOT NULL,
+			checksum char(64) NOT NULL,
+			applied_at timestamptz NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	return nil
+}
+
+// schemaMigrationsExists looks in the schema ensureSchemaMigrations creates
+// the table in, not the whole search_path.
+func schemaMigrationsExists(ctx context.Context, conn *sql.Conn) (bool, error) {
+	var exists bool
+	err := conn.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM information_schema.tables
+			WHERE table_schema = current_schema() AND table_name = 'schema_migrations'
+		)
+	`).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up schema_migrations: %w", err)
+	}
+	return exists, nil
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := map[int64]appliedMigration{}
+	for rows.Next() {
+		var record appliedMigration
+		if err := rows.Scan(&record.Version, &record.Name, &record.Checksum, &record.AppliedAt); err != nil {
+			return nil, err
+		}
+		done[record.Version] = record
+	}
+	return done, rows.Err()
+}
+
+// verify refuses to continue when an applied migration was edited after the
+// fact or is unknown to this binary, since the schema can no longer be
+// trusted to match the files.
+func (m *Migrator) verify(done map[int64]appliedMigration) error {
+	embedded := make(map[int64]Migration, len(m.Migrations))
+	for _, migration := range m.Migrations {
+		embedded[migration.Version] = migration
+	}
+	for version, record := range done {
+		migration, ok := embedded[version]
+		if !ok {
+			return fmt.Errorf("%04d_%s: %w", version, record.Name, ErrUnknownMigration)
+		}
+		if migration.Checksum != record.Checksum {
+			return fmt.Errorf("%04d_%s: %w", version, record.Name, ErrChecksumMismatch)
+		}
+	}
+	return nil
+}
+
+// run executes one migration file and records (or removes) its version in
+// the same transaction.
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, migration Migration, script string, up bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// No arguments, so the driver sends the script over the simple query
+	// protocol and multi-statement files work.
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+			migration.Version, migration.Name, migration.Checksum)
+	} else {
+		_, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,234 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gin-backend-app/internal/database"
+	"gin-backend-app/internal/testutil/pgtest"
+
+	"gorm.io/gorm"
+)
+
+// widgetMigrations are small stand-ins for the embedded files, so the tests
+// do not depend on the application schema.
+func widgetMigrations() []database.Migration {
+	return []database.Migration{
+		{Version: 1, Name: "widgets", Up: "CREATE TABLE widgets (id int PRIMARY KEY)", Down: "DROP TABLE widgets", Checksum: "c1"},
+		{Version: 2, Name: "widget_name", Up: "ALTER TABLE widgets ADD COLUMN name text", Down: "ALTER TABLE widgets DROP COLUMN name", Checksum: "c2"},
+		{Version: 3, Name: "gadgets", Up: "CREATE TABLE gadgets (id int PRIMARY KEY); CREATE INDEX gadgets_id ON gadgets (id)", Down: "DROP TABLE gadgets", Checksum: "c3"},
+	}
+}
+
+func newWidgetMigrator(t *testing.T) (*gorm.DB, *database.Migrator) {
+	t.Helper()
+	db := pgtest.NewEmpty(t)
+	migrator := newMigrator(t, db)
+	migrator.Migrations = widgetMigrations()
+	return db, migrator
+}
+
+func TestMigratorUpAndDown(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db, migrator := newWidgetMigrator(t)
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if len(applied) != 3 || applied[0].Version != 1 || applied[2].Version != 3 {
+		t.Errorf("Up applied %v, want versions 1-3 in order", versions(applied))
+	}
+	assertTables(t, db, map[string]bool{"widgets": true, "gadgets": true})
+
+	if applied, err := migrator.Up(ctx); err != nil || len(applied) != 0 {
+		t.Errorf("second Up = %v, %v; want nothing to apply", versions(applied), err)
+	}
+
+	reverted, err := migrator.Down(ctx, 2)
+	if err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+	if len(reverted) != 2 || reverted[0].Version != 3 || reverted[1].Version != 2 {
+		t.Errorf("Down reverted %v, want 3, 2", versions(reverted))
+	}
+	assertTables(t, db, map[string]bool{"widgets": true, "gadgets": false})
+	assertApplied(t, migrator, true, false, false)
+
+	// Reverting more steps than applied stops at the first migration.
+	if reverted, err := migrator.Down(ctx, 5); err != nil || len(reverted) != 1 {
+		t.Errorf("Down(5) = %v, %v; want only version 1", versions(reverted), err)
+	}
+	assertTables(t, db, map[string]bool{"widgets": false})
+
+	if _, err := migrator.Down(ctx, 0); err == nil {
+		t.Error("Down(0) succeeded")
+	}
+}
+
+func TestMigratorUpStopsAtFailingMigration(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db, migrator := newWidgetMigrator(t)
+	migrator.Migrations[1].Up = "ALTER TABLE widgets ADD COLUMN name text; SELECT * FROM no_such_table"
+
+	if _, err := migrator.Up(ctx); err == nil {
+		t.Fatal("Up succeeded despite a failing migration")
+	}
+	// The failing file is rolled back as a whole, including its record.
+	assertApplied(t, migrator, true, false, false)
+	var hasName bool
+	err := db.Raw(`SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = 'widgets' AND column_name = 'name')`).Row().Scan(&hasName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasName {
+		t.Error("column from the failed migration was kept")
+	}
+}
+
+func TestMigratorRefusesDriftedHistory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	_, migrator := newWidgetMigrator(t)
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		edited := newMigratorFrom(migrator, widgetMigrations())
+		edited.Migrations[1].Checksum = "edited"
+
+		if _, err := edited.Up(ctx); !errors.Is(err, database.ErrChecksumMismatch) {
+			t.Errorf("Up: error = %v, want ErrChecksumMismatch", err)
+		}
+		if _, err := edited.Down(ctx, 1); !errors.Is(err, database.ErrChecksumMismatch) {
+			t.Errorf("Down: error = %v, want ErrChecksumMismatch", err)
+		}
+		statuses, err := edited.Status(ctx)
+		if err != nil {
+			t.Fatalf("Status: %v", err)
+		}
+		if statuses[0].Modified || !statuses[1].Modified {
+			t.Errorf("status = %+v, want only version 2 modified", statuses)
+		}
+	})
+
+	t.Run("unknown migration", func(t *testing.T) {
+		older := newMigratorFrom(migrator, widgetMigrations()[:2])
+
+		if _, err := older.Up(ctx); !errors.Is(err, database.ErrUnknownMigration) {
+			t.Errorf("Up: error = %v, want ErrUnknownMigration", err)
+		}
+		statuses, err := older.Status(ctx)
+		if err != nil {
+			t.Fatalf("Status: %v", err)
+		}
+		if len(statuses) != 3 || !statuses[2].Missing || statuses[2].Version != 3 || statuses[2].AppliedAt == nil {
+			t.Errorf("status = %+v, want version 3 reported missing", statuses)
+		}
+	})
+
+	t.Run("no down file", func(t *testing.T) {
+		irreversible := newMigratorFrom(migrator, widgetMigrations())
+		irreversible.Migrations[2].Down = ""
+
+		if _, err := irreversible.Down(ctx, 1); !errors.Is(err, database.ErrNoDownMigration) {
+			t.Errorf("Down: error = %v, want ErrNoDownMigration", err)
+		}
+	})
+
+	// None of the refused runs changed anything.
+	assertApplied(t, migrator, true, true, true)
+}
+
+func TestMigratorStatusBeforeFirstRun(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db, migrator := newWidgetMigrator(t)
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(statuses) != 3 {
+		t.Fatalf("Status returned %d migrations, want 3", len(statuses))
+	}
+	for _, status := range statuses {
+		if status.AppliedAt != nil || status.Modified || status.Missing {
+			t.Errorf("status = %+v, want pending", status)
+		}
+	}
+	// Status only reads; it must not create the history table.
+	assertTables(t, db, map[string]bool{"schema_migrations": false})
+}
+
+func TestEmbeddedMigrationsRoundTrip(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	migrator := newMigrator(t, pgtest.NewEmpty(t))
+
+	for i, migration := range migrator.Migrations {
+		if migration.Version != int64(i+1) {
+			t.Errorf("migration %d has version %d, want consecutive versions", i, migration.Version)
+		}
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	// 0001 down drops the enum types, which live in the shared public
+	// schema under pgtest, so only the later migrations are reverted.
+	if _, err := migrator.Down(ctx, len(migrator.Migrations)-1); err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+	assertApplied(t, migrator, true, false, false, false)
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Up after Down: %v", err)
+	}
+	assertApplied(t, migrator, true, true, true, true)
+}
+
+func newMigratorFrom(m *database.Migrator, migrations []database.Migration) *database.Migrator {
+	return &database.Migrator{DB: m.DB, Migrations: migrations}
+}
+
+// assertApplied checks the applied state of m.Migrations in order.
+func assertApplied(t *testing.T, m *database.Migrator, want ...bool) {
+	t.Helper()
+	statuses, err := m.Status(context.Background())
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(statuses) != len(want) {
+		t.Fatalf("Status returned %d migrations, want %d", len(statuses), len(want))
+	}
+	for i, status := range statuses {
+		if applied := status.AppliedAt != nil; applied != want[i] {
+			t.Errorf("%04d_%s applied = %v, want %v", status.Version, status.Name, applied, want[i])
+		}
+	}
+}
+
+func assertTables(t *testing.T, db *gorm.DB, want map[string]bool) {
+	t.Helper()
+	for table, wantExists := range want {
+		var exists bool
+		err := db.Raw(`SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = ?)`, table).Row().Scan(&exists)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exists != wantExists {
+			t.Errorf("table %s exists = %v, want %v", table, exists, wantExists)
+		}
+	}
+}
+
+func versions(migrations []database.Migration) []int64 {
+	out := make([]int64, 0, len(migrations))
+	for _, migration := range migrations {
+		out = append(out, migration.Version)
+	}
+	return out
+}
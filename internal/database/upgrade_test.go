@@ -0,0 +1,76 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"gin-backend-app/internal/database"
+	"gin-backend-app/internal/testutil/pgtest"
+
+	"gorm.io/gorm"
+)
+
+// TestUpgradeFromAutoMigrate starts from the schema AutoMigrate built before
+// versioned migrations (0001 applied but not recorded, with data in it) and
+// checks that Up brings it to the current schema.
+func TestUpgradeFromAutoMigrate(t *testing.T) {
+	t.Parallel()
+	db := pgtest.NewEmpty(t)
+	migrator := newMigrator(t, db)
+
+	if err := db.Exec(migrator.Migrations[0].Up).Error; err != nil {
+		t.Fatalf("create baseline schema: %v", err)
+	}
+	exec(t, db, `INSERT INTO users (id, name, email, password) VALUES ('00000000-0000-0000-0000-000000000001', 'old', 'old@example.com', 'x')`)
+	exec(t, db, `INSERT INTO categories (id, user_id, name, group_type) VALUES ('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', 'Food', 'expense')`)
+	exec(t, db, `INSERT INTO user_budgets (user_id, category_id, amount, period_type, period_value, start_date) VALUES ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000002', 100, 'monthly', 1, '2026-01-01')`)
+
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if len(applied) != len(migrator.Migrations) {
+		t.Errorf("applied %d migrations, want %d", len(applied), len(migrator.Migrations))
+	}
+
+	var locale string
+	var digestWeekly bool
+	err = db.Raw(`SELECT locale, digest_weekly FROM users WHERE email = 'old@example.com'`).Row().Scan(&locale, &digestWeekly)
+	if err != nil {
+		t.Fatalf("read new user columns: %v", err)
+	}
+	if locale != "en" || digestWeekly {
+		t.Errorf("locale, digest_weekly = %q, %v; want en, false", locale, digestWeekly)
+	}
+
+	// The baseline index allowed one budget per user.
+	exec(t, db, `INSERT INTO user_budgets (user_id, category_id, amount, period_type, period_value, start_date) VALUES ('00000000-0000-0000-0000-000000000001', '00000000-0000-0000-0000-000000000002', 200, 'weekly', 1, '2026-01-01')`)
+
+	for _, table := range []string{"savings_goals", "transaction_splits", "tags", "transaction_tags", "attachments", "email_outbox", "notifications", "budget_alerts", "digest_deliveries"} {
+		var exists bool
+		err := db.Raw(`SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = ?)`, table).Row().Scan(&exists)
+		if err != nil || !exists {
+			t.Errorf("table %s exists = %v, %v", table, exists, err)
+		}
+	}
+}
+
+func newMigrator(t *testing.T, db *gorm.DB) *database.Migrator {
+	t.Helper()
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	migrator, err := database.NewMigrator(sqlDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return migrator
+}
+
+func exec(t *testing.T, db *gorm.DB, query string) {
+	t.Helper()
+	if err := db.Exec(query).Error; err != nil {
+		t.Fatalf("%s: %v", query, err)
+	}
+}
@@ -1,6 +1,8 @@
 package common
 
 import (
+	"gin-backend-app/internal/apperror"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -11,11 +13,14 @@ type Response struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
-// ErrorResponse represents error response
+// ErrorResponse represents error response. Code is stable and meant for
+// clients to localize on; Details lists invalid fields of the request.
 type ErrorResponse struct {
-	Success bool   `json:"success" example:"false"`
-	Message string `json:"message" example:"Something went wrong"`
-	Error   string `json:"error,omitempty" example:"Detailed error message"`
+	Success bool                  `json:"success" example:"false"`
+	Code    apperror.Code         `json:"code" example:"VALIDATION_FAILED"`
+	Message string                `json:"message" example:"Something went wrong"`
+	Error   string                `json:"error,omitempty" example:"Detailed error message"`
+	Details []apperror.FieldError `json:"details,omitempty"`
 }
 
 // SendResponse sends success response
@@ -27,10 +32,21 @@ func SendResponse(c *gin.Context, code int, data interface{}, message string) {
 	})
 }
 
-// SendError sends error response
+// SendError sends error response with the generic code of the status
 func SendError(c *gin.Context, code int, message string) {
 	c.JSON(code, ErrorResponse{
 		Success: false,
+		Code:    apperror.CodeForStatus(code),
 		Message: message,
 	})
+}
+
+// SendAppError sends the error response of a service error
+func SendAppError(c *gin.Context, err *apperror.Error) {
+	c.JSON(err.Code.Status(), ErrorResponse{
+		Success: false,
+		Code:    err.Code,
+		Message: err.Message,
+		Details: err.Details,
+	})
 }
\ No newline at end of file
@@ -0,0 +1,8 @@
+package request
+
+// ListNotificationsQuery represents notification listing filters
+type ListNotificationsQuery struct {
+	Unread bool `form:"unread" example:"true"`
+	Page   int  `form:"page" binding:"omitempty,min=1" example:"1"`
+	Limit  int  `form:"limit" binding:"omitempty,min=1,max=100" example:"20"`
+}
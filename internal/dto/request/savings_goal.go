@@ -0,0 +1,21 @@
+package request
+
+// CreateSavingsGoalRequest represents savings goal creation request
+type CreateSavingsGoalRequest struct {
+	Name         string  `json:"name" validate:"required,min=1,max=100" example:"Emergency fund" binding:"required,min=1,max=100"`
+	TargetAmount float64 `json:"target_amount" validate:"required,gt=0" example:"15000000" binding:"required,gt=0"`
+	CategoryID   string  `json:"category_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid"`
+	StartDate    string  `json:"start_date" validate:"omitempty,datetime=2006-01-02" example:"2026-01-01" binding:"omitempty,datetime=2006-01-02"`
+	Deadline     string  `json:"deadline" validate:"required,datetime=2006-01-02" example:"2026-12-31" binding:"required,datetime=2006-01-02"`
+	Description  *string `json:"description" example:"Six months of living costs"`
+}
+
+// UpdateSavingsGoalRequest represents savings goal update request
+type UpdateSavingsGoalRequest struct {
+	Name         string  `json:"name" validate:"required,min=1,max=100" example:"Emergency fund" binding:"required,min=1,max=100"`
+	TargetAmount float64 `json:"target_amount" validate:"required,gt=0" example:"15000000" binding:"required,gt=0"`
+	CategoryID   string  `json:"category_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid"`
+	StartDate    string  `json:"start_date" validate:"required,datetime=2006-01-02" example:"2026-01-01" binding:"required,datetime=2006-01-02"`
+	Deadline     string  `json:"deadline" validate:"required,datetime=2006-01-02" example:"2026-12-31" binding:"required,datetime=2006-01-02"`
+	Description  *string `json:"description" example:"Six months of living costs"`
+}
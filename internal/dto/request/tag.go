@@ -0,0 +1,13 @@
+package request
+
+// TagRequest represents tag create and update request
+type TagRequest struct {
+	Name  string `json:"name" validate:"required,min=1,max=50" example:"trip-bali-2026" binding:"required,min=1,max=50"`
+	Color string `json:"color" validate:"omitempty,hex_color" example:"#1E88E5" binding:"omitempty,hex_color"`
+}
+
+// TagSpendingQuery represents the period of a per-tag spending aggregation
+type TagSpendingQuery struct {
+	From string `form:"from" binding:"required,datetime=2006-01-02" example:"2026-10-01"`
+	To   string `form:"to" binding:"required,datetime=2006-01-02" example:"2026-10-31"`
+}
@@ -0,0 +1,33 @@
+package request
+
+// TransactionSplitRequest represents a single split line of a transaction
+type TransactionSplitRequest struct {
+	CategoryID  string  `json:"category_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required,uuid"`
+	Amount      float64 `json:"amount" validate:"required,gt=0" example:"150000" binding:"required,gt=0"`
+	Description *string `json:"description" example:"Groceries"`
+}
+
+// TransactionRequest represents transaction create and update request.
+// Either category_id or splits must be provided; split amounts must sum to amount.
+// Updating with an empty splits list removes existing splits.
+type TransactionRequest struct {
+	CategoryID  string                    `json:"category_id" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000" binding:"omitempty,uuid"`
+	Type        string                    `json:"type" validate:"required,oneof=income expense" example:"expense" binding:"required,oneof=income expense"`
+	Amount      float64                   `json:"amount" validate:"required,gt=0" example:"200000" binding:"required,gt=0"`
+	Description *string                   `json:"description" example:"Supermarket"`
+	Date        string                    `json:"date" validate:"required,datetime=2006-01-02" example:"2026-10-01" binding:"required,datetime=2006-01-02"`
+	Splits      []TransactionSplitRequest `json:"splits" validate:"omitempty,dive" binding:"omitempty,dive"`
+	Tags        []string                  `json:"tags" validate:"omitempty,max=20,dive,min=1,max=50" example:"trip-bali-2026,reimbursable" binding:"omitempty,max=20,dive,min=1,max=50"`
+}
+
+// ListTransactionsQuery represents transaction listing and export filters.
+// Tags is a comma separated list; transactions must carry all of them.
+type ListTransactionsQuery struct {
+	From       string `form:"from" binding:"omitempty,datetime=2006-01-02" example:"2026-10-01"`
+	To         string `form:"to" binding:"omitempty,datetime=2006-01-02" example:"2026-10-31"`
+	CategoryID string `form:"category_id" binding:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Type       string `form:"type" binding:"omitempty,oneof=income expense" example:"expense"`
+	Tags       string `form:"tags" example:"trip-bali-2026,reimbursable"`
+	Page       int    `form:"page" binding:"omitempty,min=1" example:"1"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=100" example:"20"`
+}
@@ -4,7 +4,9 @@ package request
 type CreateUserRequest struct {
 	Name     string `json:"name" validate:"required,min=3,max=50" example:"john_doe" binding:"required"`
 	Email    string `json:"email" validate:"required,email" example:"john@example.com" binding:"required,email"`
-	Password string `json:"password" validate:"required,min=8" example:"MySecurePass123!" binding:"required,min=8"`
+	Password string `json:"password" validate:"required" example:"MySecurePass123!" binding:"required"`
+	// Locale selects the language of emails sent to the user ("en" or "id").
+	Locale   string `json:"locale" validate:"omitempty,oneof=en id" example:"id" binding:"omitempty,oneof=en id"`
 }
 
 // LoginUserRequest represents user login request
@@ -20,7 +22,14 @@ type RequestChangePasswordOtpRequest struct {
 
 // ChangePasswordRequest represents change password request
 type ChangePasswordRequest struct {
-	NewPassword     string `json:"new_password" validate:"required,min=8" example:"MyNewSecurePass123!" binding:"required,min=8"`
+	NewPassword     string `json:"new_password" validate:"required" example:"MyNewSecurePass123!" binding:"required"`
+	ConfirmPassword string `json:"confirm_password" validate:"required" example:"MyNewSecurePass123!" binding:"required"`
+}
+
+// UpdatePasswordRequest represents a signed in user's password change
+type UpdatePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required" example:"MySecurePass123!" binding:"required"`
+	NewPassword     string `json:"new_password" validate:"required" example:"MyNewSecurePass123!" binding:"required"`
 	ConfirmPassword string `json:"confirm_password" validate:"required" example:"MyNewSecurePass123!" binding:"required"`
 }
 
@@ -28,4 +37,11 @@ type ChangePasswordRequest struct {
 type VerifyOTPAndEmailRequest struct {
 	Email    string `json:"email" validate:"required,email" example:"john@example.com" binding:"required,email"`
 	TokenOtp string `json:"token_otp" validate:"required,len=6" example:"ABCD12" binding:"required,len=6"`
-}
\ No newline at end of file
+}
+// UpdatePreferencesRequest represents changes to the user's preferences.
+// Omitted fields keep their current value.
+type UpdatePreferencesRequest struct {
+	Locale        *string `json:"locale" validate:"omitempty,oneof=en id" example:"id" binding:"omitempty,oneof=en id"`
+	DigestWeekly  *bool   `json:"digest_weekly" example:"true"`
+	DigestMonthly *bool   `json:"digest_monthly" example:"false"`
+}
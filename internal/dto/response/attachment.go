@@ -0,0 +1,20 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AttachmentResponse represents a transaction attachment with short-lived download URLs
+type AttachmentResponse struct {
+	ID            uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TransactionID uuid.UUID `json:"transaction_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	FileName      string    `json:"file_name" example:"receipt.jpg"`
+	ContentType   string    `json:"content_type" example:"image/jpeg"`
+	Size          int64     `json:"size" example:"245760"`
+	URL           string    `json:"url" example:"http://localhost:8080/api/v1/attachments/550e8400-e29b-41d4-a716-446655440000/content?variant=original&expires=1767225600&signature=..."`
+	ThumbnailURL  *string   `json:"thumbnail_url,omitempty" example:"http://localhost:8080/api/v1/attachments/550e8400-e29b-41d4-a716-446655440000/content?variant=thumbnail&expires=1767225600&signature=..."`
+	URLExpiresAt  time.Time `json:"url_expires_at" example:"2026-01-01T00:00:00Z"`
+	CreatedAt     time.Time `json:"created_at" example:"2026-01-01T00:00:00Z"`
+}
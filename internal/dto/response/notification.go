@@ -0,0 +1,33 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationResponse represents an in-app notification
+type NotificationResponse struct {
+	ID        uuid.UUID      `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Type      string         `json:"type" example:"budget_threshold"`
+	Title     string         `json:"title" example:"Budget Food reached 80%"`
+	Body      string         `json:"body" example:"You have spent 1600000.00 of your 2000000.00 Food budget (80%) for 2026-10-01 - 2026-10-31."`
+	Data      map[string]any `json:"data"`
+	IsRead    bool           `json:"is_read" example:"false"`
+	ReadAt    *time.Time     `json:"read_at"`
+	CreatedAt time.Time      `json:"created_at" example:"2026-10-18T09:30:00Z"`
+}
+
+// NotificationListResponse represents a paginated list of notifications
+type NotificationListResponse struct {
+	Items  []NotificationResponse `json:"items"`
+	Total  int64                  `json:"total" example:"12"`
+	Unread int64                  `json:"unread" example:"3"`
+	Page   int                    `json:"page" example:"1"`
+	Limit  int                    `json:"limit" example:"20"`
+}
+
+// NotificationReadAllResponse reports how many notifications were marked as read
+type NotificationReadAllResponse struct {
+	Updated int64 `json:"updated" example:"3"`
+}
@@ -0,0 +1,54 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CategoryBreakdownResponse represents the total of a category within a period
+type CategoryBreakdownResponse struct {
+	CategoryID   uuid.UUID `json:"category_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CategoryName string    `json:"category_name" example:"Groceries"`
+	Type         string    `json:"type" example:"expense"`
+	Total        float64   `json:"total" example:"1250000"`
+	Count        int64     `json:"count" example:"12"`
+}
+
+// PeriodReportResponse represents a generated weekly or monthly report
+type PeriodReportResponse struct {
+	ID           uuid.UUID                   `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	PeriodType   string                      `json:"period_type" example:"monthly"`
+	PeriodValue  int                         `json:"period_value" example:"10"`
+	PeriodStart  time.Time                   `json:"period_start" example:"2026-10-01T00:00:00Z"`
+	PeriodEnd    time.Time                   `json:"period_end" example:"2026-10-31T00:00:00Z"`
+	TotalIncome  float64                     `json:"total_income" example:"8000000"`
+	TotalExpense float64                     `json:"total_expense" example:"5500000"`
+	NetFlow      float64                     `json:"net_flow" example:"2500000"`
+	Categories   []CategoryBreakdownResponse `json:"categories"`
+	GeneratedAt  time.Time                   `json:"generated_at" example:"2026-11-01T00:00:00Z"`
+}
+
+// BudgetStatusResponse represents spending against an active budget in its current period
+type BudgetStatusResponse struct {
+	BudgetID     uuid.UUID `json:"budget_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CategoryID   uuid.UUID `json:"category_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CategoryName string    `json:"category_name" example:"Groceries"`
+	PeriodType   string    `json:"period_type" example:"monthly"`
+	PeriodStart  time.Time `json:"period_start" example:"2026-10-01T00:00:00Z"`
+	PeriodEnd    time.Time `json:"period_end" example:"2026-10-31T00:00:00Z"`
+	Amount       float64   `json:"amount" example:"2000000"`
+	Spent        float64   `json:"spent" example:"1250000"`
+	Remaining    float64   `json:"remaining" example:"750000"`
+	PercentUsed  float64   `json:"percent_used" example:"62.5"`
+	IsExceeded   bool      `json:"is_exceeded" example:"false"`
+}
+
+// BudgetEvaluationResponse represents a stored budget_evaluation analysis
+type BudgetEvaluationResponse struct {
+	ID           uuid.UUID      `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	AnalysisType string         `json:"analysis_type" example:"budget_evaluation"`
+	InputData    map[string]any `json:"input_data"`
+	OutputData   map[string]any `json:"output_data"`
+	CreatedAt    time.Time      `json:"created_at" example:"2026-10-18T08:00:00Z"`
+}
@@ -0,0 +1,33 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavingsGoalResponse represents a savings goal with its computed progress
+type SavingsGoalResponse struct {
+	ID           uuid.UUID            `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name         string               `json:"name" example:"Emergency fund"`
+	TargetAmount float64              `json:"target_amount" example:"15000000"`
+	CategoryID   *uuid.UUID           `json:"category_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CategoryName *string              `json:"category_name,omitempty" example:"Savings"`
+	StartDate    time.Time            `json:"start_date" example:"2026-01-01T00:00:00Z"`
+	Deadline     time.Time            `json:"deadline" example:"2026-12-31T00:00:00Z"`
+	Description  *string              `json:"description,omitempty" example:"Six months of living costs"`
+	Progress     GoalProgressResponse `json:"progress"`
+	CreatedAt    time.Time            `json:"created_at" example:"2026-01-01T00:00:00Z"`
+	UpdatedAt    time.Time            `json:"updated_at" example:"2026-01-01T00:00:00Z"`
+}
+
+// GoalProgressResponse represents contribution progress and projections for a goal
+type GoalProgressResponse struct {
+	ContributedAmount           float64    `json:"contributed_amount" example:"4500000"`
+	RemainingAmount             float64    `json:"remaining_amount" example:"10500000"`
+	PercentComplete             float64    `json:"percent_complete" example:"30"`
+	RequiredMonthlyContribution float64    `json:"required_monthly_contribution" example:"1166666.67"`
+	AverageMonthlyContribution  float64    `json:"average_monthly_contribution" example:"1500000"`
+	ProjectedCompletionDate     *time.Time `json:"projected_completion_date,omitempty" example:"2026-10-15T00:00:00Z"`
+	Status                      string     `json:"status" example:"on_track"`
+}
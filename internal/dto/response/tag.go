@@ -0,0 +1,25 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TagResponse represents tag data in API responses
+type TagResponse struct {
+	ID        uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name      string    `json:"name" example:"trip-bali-2026"`
+	Color     string    `json:"color" example:"#1E88E5"`
+	CreatedAt time.Time `json:"created_at" example:"2026-10-01T00:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2026-10-01T00:00:00Z"`
+}
+
+// TagSpendingResponse represents income and expense totals of a tag within a period
+type TagSpendingResponse struct {
+	TagID   uuid.UUID `json:"tag_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name    string    `json:"name" example:"trip-bali-2026"`
+	Income  float64   `json:"income" example:"0"`
+	Expense float64   `json:"expense" example:"4350000"`
+	Count   int64     `json:"count" example:"9"`
+}
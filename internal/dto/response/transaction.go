@@ -0,0 +1,51 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransactionSplitResponse represents a split line of a transaction
+type TransactionSplitResponse struct {
+	ID           uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CategoryID   uuid.UUID `json:"category_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CategoryName string    `json:"category_name" example:"Groceries"`
+	Amount       float64   `json:"amount" example:"150000"`
+	Description  *string   `json:"description,omitempty" example:"Groceries"`
+}
+
+// TransactionResponse represents transaction data in API responses
+type TransactionResponse struct {
+	ID           uuid.UUID                  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CategoryID   uuid.UUID                  `json:"category_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CategoryName string                     `json:"category_name" example:"Groceries"`
+	Type         string                     `json:"type" example:"expense"`
+	Amount       float64                    `json:"amount" example:"200000"`
+	Description  *string                    `json:"description,omitempty" example:"Supermarket"`
+	Date         time.Time                  `json:"date" example:"2026-10-01T00:00:00Z"`
+	Splits       []TransactionSplitResponse `json:"splits,omitempty"`
+	Tags         []string                   `json:"tags" example:"trip-bali-2026,reimbursable"`
+	CreatedAt    time.Time                  `json:"created_at" example:"2026-10-01T00:00:00Z"`
+	UpdatedAt    time.Time                  `json:"updated_at" example:"2026-10-01T00:00:00Z"`
+}
+
+// TransactionListResponse represents a paginated list of transactions
+type TransactionListResponse struct {
+	Items []TransactionResponse `json:"items"`
+	Total int64                 `json:"total" example:"42"`
+	Page  int                   `json:"page" example:"1"`
+	Limit int                   `json:"limit" example:"20"`
+}
+
+// TransactionImportRowError describes a rejected row of an import file
+type TransactionImportRowError struct {
+	Row     int    `json:"row" example:"3"`
+	Message string `json:"message" example:"split amounts must sum to the transaction amount"`
+}
+
+// TransactionImportResponse represents the result of a transaction import
+type TransactionImportResponse struct {
+	Imported int                         `json:"imported" example:"25"`
+	Errors   []TransactionImportRowError `json:"errors,omitempty"`
+}
@@ -22,3 +22,10 @@ type LoginResponse struct {
 	User  UserResponse `json:"user"`
 	Token string       `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
 }
+
+// UserPreferencesResponse represents the user's email and digest preferences
+type UserPreferencesResponse struct {
+	Locale        string `json:"locale" example:"id"`
+	DigestWeekly  bool   `json:"digest_weekly" example:"true"`
+	DigestMonthly bool   `json:"digest_monthly" example:"false"`
+}
@@ -0,0 +1,25 @@
+//go:build linux || darwin
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+)
+
+// DiskSpace fails when the filesystem holding dir has less than minFree
+// bytes available to the application.
+func DiskSpace(dir string, minFree uint64) Check {
+	return func(ctx context.Context) error {
+		var stat syscall.Statfs_t
+		if err := syscall.Statfs(dir, &stat); err != nil {
+			return fmt.Errorf("failed to stat %s: %w", dir, err)
+		}
+		free := stat.Bavail * uint64(stat.Bsize)
+		if free < minFree {
+			return fmt.Errorf("%s has %d bytes free, below the minimum of %d", dir, free, minFree)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,22 @@
+//go:build linux || darwin
+
+package health
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestDiskSpace(t *testing.T) {
+	dir := t.TempDir()
+	if err := DiskSpace(dir, 1)(context.Background()); err != nil {
+		t.Errorf("DiskSpace(1 byte): %v", err)
+	}
+	if err := DiskSpace(dir, math.MaxUint64)(context.Background()); err == nil {
+		t.Error("DiskSpace(max) passed, want too little free space")
+	}
+	if err := DiskSpace(dir+"/missing", 1)(context.Background()); err == nil {
+		t.Error("DiskSpace on a missing directory passed")
+	}
+}
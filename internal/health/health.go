@@ -0,0 +1,125 @@
+// Package health runs registered dependency checks for the readiness probe.
+// Results are cached briefly so frequent probes from several load balancers
+// do not hammer the database or the mail server.
+package health
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const (
+	StatusUp = "up"
+	// StatusDegraded means only non-critical checks fail; the service still
+	// accepts traffic.
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+// Check reports whether a dependency is usable; nil means healthy.
+type Check func(ctx context.Context) error
+
+type check struct {
+	name     string
+	critical bool
+	run      Check
+}
+
+// Registry holds the checks of the application and the last report.
+type Registry struct {
+	timeout  time.Duration
+	cacheTTL time.Duration
+	checks   []check
+
+	// mu is held while checks run, so concurrent probes wait for and share
+	// one run instead of starting their own.
+	mu       sync.Mutex
+	last     Report
+	lastTime time.Time
+}
+
+// Report is the aggregate result of every check. Errors are logged, not
+// reported, so probes never expose connection strings or hostnames.
+type Report struct {
+	Status    string                 `json:"status"`
+	Checks    map[string]CheckResult `json:"checks"`
+	CheckedAt time.Time              `json:"checked_at"`
+}
+
+type CheckResult struct {
+	Status     string `json:"status"`
+	Critical   bool   `json:"critical"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// NewRegistry returns an empty registry. Each check gets timeout to finish
+// and a report is reused for cacheTTL.
+func NewRegistry(timeout, cacheTTL time.Duration) *Registry {
+	return &Registry{timeout: timeout, cacheTTL: cacheTTL}
+}
+
+// Register adds a check. A failing critical check makes the service down
+// (not ready); a failing non-critical one only degrades it. Checks are
+// registered while the application is built, before Run is called.
+func (r *Registry) Register(name string, critical bool, run Check) {
+	r.checks = append(r.checks, check{name: name, critical: critical, run: run})
+}
+
+// Run returns the current report, running every check in parallel when the
+// cached one is older than the cache TTL.
+func (r *Registry) Run(ctx context.Context) Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.lastTime.IsZero() && time.Since(r.lastTime) < r.cacheTTL {
+		return r.last
+	}
+
+	// The result is shared with other probes, so a probe that disconnects
+	// must not cancel the checks.
+	ctx = context.WithoutCancel(ctx)
+
+	results := make([]CheckResult, len(r.checks))
+	var wg sync.WaitGroup
+	for i, c := range r.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.runCheck(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]CheckResult, len(r.checks)), CheckedAt: time.Now()}
+	for i, c := range r.checks {
+		result := results[i]
+		report.Checks[c.name] = result
+		if result.Status == StatusUp {
+			continue
+		}
+		if c.critical {
+			report.Status = StatusDown
+		} else if report.Status == StatusUp {
+			report.Status = StatusDegraded
+		}
+	}
+
+	r.last, r.lastTime = report, report.CheckedAt
+	return report
+}
+
+func (r *Registry) runCheck(ctx context.Context, c check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.run(ctx)
+	result := CheckResult{Status: StatusUp, Critical: c.critical, DurationMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = StatusDown
+		slog.WarnContext(ctx, "health check failed", "check", c.name, "critical", c.critical, "error", err)
+	}
+	return result
+}
@@ -0,0 +1,123 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func up(context.Context) error   { return nil }
+func down(context.Context) error { return errors.New("dial tcp 10.0.0.5:5432: connection refused") }
+
+func TestRunAggregatesStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		critical Check
+		optional Check
+		want     string
+	}{
+		{"all up", up, up, StatusUp},
+		{"optional down", up, down, StatusDegraded},
+		{"critical down", down, up, StatusDown},
+		{"both down", down, down, StatusDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry(time.Second, 0)
+			registry.Register("database", true, tt.critical)
+			registry.Register("smtp", false, tt.optional)
+
+			report := registry.Run(context.Background())
+			if report.Status != tt.want {
+				t.Errorf("status = %s, want %s", report.Status, tt.want)
+			}
+			if len(report.Checks) != 2 || !report.Checks["database"].Critical || report.Checks["smtp"].Critical {
+				t.Errorf("checks = %+v", report.Checks)
+			}
+		})
+	}
+}
+
+func TestRunEmptyRegistryIsUp(t *testing.T) {
+	if report := NewRegistry(time.Second, 0).Run(context.Background()); report.Status != StatusUp {
+		t.Errorf("status = %s, want up", report.Status)
+	}
+}
+
+func TestRunTimesOutSlowChecks(t *testing.T) {
+	registry := NewRegistry(20*time.Millisecond, 0)
+	registry.Register("slow", true, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	start := time.Now()
+	report := registry.Run(context.Background())
+	if report.Status != StatusDown || report.Checks["slow"].Status != StatusDown {
+		t.Errorf("report = %+v, want the slow check down", report)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Run took %s, want it bounded by the check timeout", elapsed)
+	}
+}
+
+func TestRunCachesReport(t *testing.T) {
+	var runs atomic.Int32
+	registry := NewRegistry(time.Second, 50*time.Millisecond)
+	registry.Register("counted", true, func(context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	first := registry.Run(context.Background())
+	second := registry.Run(context.Background())
+	if runs.Load() != 1 || !second.CheckedAt.Equal(first.CheckedAt) {
+		t.Errorf("checks ran %d times within the cache TTL, want 1", runs.Load())
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if registry.Run(context.Background()); runs.Load() != 2 {
+		t.Errorf("checks ran %d times after the cache TTL, want 2", runs.Load())
+	}
+}
+
+func TestConcurrentProbesShareOneRun(t *testing.T) {
+	var runs atomic.Int32
+	release := make(chan struct{})
+	registry := NewRegistry(time.Second, time.Minute)
+	registry.Register("blocking", true, func(context.Context) error {
+		runs.Add(1)
+		<-release
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			registry.Run(context.Background())
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if runs.Load() != 1 {
+		t.Errorf("checks ran %d times for concurrent probes, want 1", runs.Load())
+	}
+}
+
+func TestRunIgnoresProbeCancellation(t *testing.T) {
+	registry := NewRegistry(time.Second, 0)
+	registry.Register("database", true, func(ctx context.Context) error { return ctx.Err() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if report := registry.Run(ctx); report.Status != StatusUp {
+		t.Errorf("status = %s, want up: a disconnected probe must not fail the shared run", report.Status)
+	}
+}
@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+var _ gormlogger.Interface = (*GormLogger)(nil)
+var _ gorm.ParamsFilter = (*GormLogger)(nil)
+
+// unfilledPlaceholder matches the "$1$" GORM renders for a placeholder
+// without a parameter.
+var unfilledPlaceholder = regexp.MustCompile(`\$(\d+)\$`)
+
+// GormLogger reports failed queries and queries slower than SlowThreshold
+// through slog, with the request id of the query's context. At debug level
+// every query is logged. Statements are logged with their $n placeholders,
+// never with the parameters, which may hold passwords, tokens or personal
+// data.
+type GormLogger struct {
+	SlowThreshold time.Duration
+	level         gormlogger.LogLevel
+}
+
+func NewGormLogger(slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{SlowThreshold: slowThreshold, level: gormlogger.Warn}
+}
+
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, data ...any) {
+	if l.level >= gormlogger.Info {
+		slog.InfoContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, data ...any) {
+	if l.level >= gormlogger.Warn {
+		slog.WarnContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, data ...any) {
+	if l.level >= gormlogger.Error {
+		slog.ErrorContext(ctx, fmt.Sprintf(msg, data...))
+	}
+}
+
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+
+	switch {
+	// Not found is an expected outcome that the repositories handle.
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= gormlogger.Error:
+		sql, rows := l.statement(fc)
+		slog.ErrorContext(ctx, "query failed", "error", err, "duration_ms", elapsed.Milliseconds(), "rows", rows, "sql", sql)
+	case l.SlowThreshold > 0 && elapsed > l.SlowThreshold && l.level >= gormlogger.Warn:
+		sql, rows := l.statement(fc)
+		slog.WarnContext(ctx, "slow query", "duration_ms", elapsed.Milliseconds(), "threshold_ms", l.SlowThreshold.Milliseconds(), "rows", rows, "sql", sql)
+	case slog.Default().Enabled(ctx, slog.LevelDebug):
+		sql, rows := l.statement(fc)
+		slog.DebugContext(ctx, "query", "duration_ms", elapsed.Milliseconds(), "rows", rows, "sql", sql)
+	}
+}
+
+func (l *GormLogger) statement(fc func() (string, int64)) (string, int64) {
+	sql, rows := fc()
+	return unfilledPlaceholder.ReplaceAllString(sql, "$$$1"), rows
+}
+
+// ParamsFilter drops the parameters before GORM renders the statement.
+func (l *GormLogger) ParamsFilter(ctx context.Context, sql string, params ...any) (string, []any) {
+	return sql, nil
+}
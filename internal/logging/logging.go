@@ -0,0 +1,56 @@
+// Package logging configures log/slog for the application: JSON or text
+// output, request and user ids taken from the context of every record, and a
+// GORM logger that reports slow and failed queries.
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Config selects the log level and output format.
+type Config struct {
+	// Level is debug, info, warn or error.
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+	// Format is "json" for log collectors or "text" for reading in a
+	// terminal.
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+}
+
+// ParseLevel parses a level name such as "info" or "warn".
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q", name)
+	}
+	return level, nil
+}
+
+// Setup makes a logger built from cfg the slog default. Output of the
+// standard log package goes through it as well.
+func Setup(cfg Config) error {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return err
+	}
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch cfg.Format {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	case FormatText:
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	default:
+		return fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+
+	slog.SetDefault(slog.New(contextHandler{handler}))
+	return nil
+}
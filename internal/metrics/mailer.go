@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"context"
+	"gin-backend-app/pkg/utils"
+)
+
+// instrumentedMailer counts the sends of the mailer it wraps.
+type instrumentedMailer struct {
+	utils.Mailer
+}
+
+// InstrumentMailer returns m counting every send by outcome.
+func InstrumentMailer(m utils.Mailer) utils.Mailer {
+	return instrumentedMailer{m}
+}
+
+func (m instrumentedMailer) Send(ctx context.Context, msg utils.Message) error {
+	err := m.Mailer.Send(ctx, msg)
+	emailsSent.WithLabelValues(outcome(err)).Inc()
+	return err
+}
@@ -0,0 +1,132 @@
+// Package metrics defines the Prometheus metrics of the application and
+// serves them on /metrics. Collectors live on the default registry, next to
+// the Go runtime and process collectors it already carries.
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "traspac"
+
+// Outcome label values.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Transaction sources for TransactionsCreated.
+const (
+	SourceAPI    = "api"
+	SourceImport = "import"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	cronRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cron_job_runs_total",
+		Help:      "Cron job runs by job and outcome.",
+	}, []string{"job", "outcome"})
+
+	cronDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "cron_job_duration_seconds",
+		Help:      "Cron job run duration by job.",
+		Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300, 900},
+	}, []string{"job"})
+
+	emailsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "emails_sent_total",
+		Help:      "Email delivery attempts by outcome.",
+	}, []string{"outcome"})
+
+	registrations = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "user_registrations_total",
+		Help:      "Users registered.",
+	})
+
+	verifications = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "email_verifications_total",
+		Help:      "Email addresses verified with an OTP.",
+	})
+
+	transactionsCreated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transactions_created_total",
+		Help:      "Transactions created by source (api or import).",
+	}, []string{"source"})
+)
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// RegisterDBStats exposes the connection pool statistics of db. Registering
+// the same database twice is not an error.
+func RegisterDBStats(db *sql.DB, name string) error {
+	err := prometheus.Register(collectors.NewDBStatsCollector(db, name))
+	var already prometheus.AlreadyRegisteredError
+	if errors.As(err, &already) {
+		return nil
+	}
+	return err
+}
+
+// ObserveRequest records one served HTTP request. route is the route
+// pattern, never the raw path, to keep the label set bounded.
+func ObserveRequest(method, route string, status int, elapsed time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpDuration.WithLabelValues(method, route, code).Observe(elapsed.Seconds())
+}
+
+// ObserveCronJob records one run of a cron job.
+func ObserveCronJob(job string, elapsed time.Duration, err error) {
+	cronRuns.WithLabelValues(job, outcome(err)).Inc()
+	cronDuration.WithLabelValues(job).Observe(elapsed.Seconds())
+}
+
+func UserRegistered() {
+	registrations.Inc()
+}
+
+func EmailVerified() {
+	verifications.Inc()
+}
+
+func TransactionsCreated(source string, n int) {
+	transactionsCreated.WithLabelValues(source).Add(float64(n))
+}
+
+func outcome(err error) string {
+	if err != nil {
+		return OutcomeFailure
+	}
+	return OutcomeSuccess
+}
@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"gin-backend-app/internal/apperror"
 	dto "gin-backend-app/internal/dto/common"
+	"gin-backend-app/internal/logging"
 	"gin-backend-app/pkg/utils"
 	"net/http"
 
@@ -10,15 +12,21 @@ import (
 
 func AuthMiddleware() gin.HandlerFunc {
     return func(c *gin.Context) {
+        if c.GetHeader("Authorization") == "" {
+            dto.SendError(c, http.StatusUnauthorized, "Authentication required")
+            c.Abort()
+            return
+        }
+
         claims, err := utils.GetClaimsFromHeader(c)
         if err != nil {
-            dto.SendError(c, http.StatusUnauthorized, "Authentication required")
+            dto.SendAppError(c, apperror.New(apperror.CodeAuthTokenInvalid, "Invalid or expired token"))
             c.Abort()
             return
         }
 
         if claims.UserID.String() == "" {
-            dto.SendError(c, http.StatusUnauthorized, "Invalid user ID in token")
+            dto.SendAppError(c, apperror.New(apperror.CodeAuthTokenInvalid, "Invalid user ID in token"))
             c.Abort()
             return
         }
@@ -27,6 +35,7 @@ func AuthMiddleware() gin.HandlerFunc {
         c.Set("user_id", claims.UserID)        
         c.Set("user_email", claims.Email)      
         c.Set("user_name", claims.Name)     
+        c.Request = c.Request.WithContext(logging.WithUserID(c.Request.Context(), claims.UserID.String()))
 
         c.Next()
     }
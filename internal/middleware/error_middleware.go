@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"gin-backend-app/internal/apperror"
+	dto "gin-backend-app/internal/dto/common"
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorMiddleware writes the response of handlers that end with c.Error.
+// The status and code come from the *apperror.Error in the chain; any other
+// error is logged and answered with a generic 500, so driver and I/O error
+// text never reaches the client.
+func ErrorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		appErr := apperror.From(err)
+		if appErr.Code == apperror.CodeInternal {
+			slog.ErrorContext(c.Request.Context(), "request failed", "error", err)
+		}
+		dto.SendAppError(c, appErr)
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"gin-backend-app/internal/metrics"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsMiddleware records the count and latency of every request by route
+// pattern. Requests that match no route share the "unmatched" route so
+// scanners cannot grow the label set.
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
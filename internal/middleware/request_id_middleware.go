@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"gin-backend-app/internal/logging"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID limits incoming ids to what is safe to echo in a header
+// and write to the logs.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestIDMiddleware gives every request an id: the X-Request-ID sent by
+// the client or proxy when it is well formed, a new UUID otherwise. The id is
+// returned in the response header and carried by the request context, so
+// every log record of the request includes it.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = uuid.NewString()
+		}
+
+		c.Header(RequestIDHeader, id)
+		c.Set("request_id", id)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), id))
+
+		c.Next()
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	dto "gin-backend-app/internal/dto/common"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimeoutMiddleware puts a deadline on the request context. Handlers pass
+// c.Request.Context() down to the database and outbound calls, which are
+// cancelled once it passes or the client goes away. Routes in skip (matched
+// against the route pattern, e.g. "/api/v1/notifications/stream") stream
+// their response for as long as the client stays and get no deadline.
+func TimeoutMiddleware(timeout time.Duration, skip ...string) gin.HandlerFunc {
+	skipped := make(map[string]bool, len(skip))
+	for _, path := range skip {
+		skipped[path] = true
+	}
+
+	return func(c *gin.Context) {
+		if skipped[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			dto.SendError(c, http.StatusGatewayTimeout, "Request timed out")
+		}
+	}
+}
@@ -13,8 +13,8 @@ type AILog struct {
     TransactionID *uuid.UUID             `json:"transaction_id" gorm:"type:uuid;index"`
     CategoryID    *uuid.UUID             `json:"category_id" gorm:"type:uuid;index"`
 	AnalysisType  AiAnalysisType `gorm:"type:ai_analysis_type_enum;not null"`
-    InputData     map[string]interface{} `json:"input_data" gorm:"type:jsonb"`
-    OutputData    map[string]interface{} `json:"output_data" gorm:"type:jsonb"`
+    InputData     map[string]interface{} `json:"input_data" gorm:"type:jsonb;serializer:json"`
+    OutputData    map[string]interface{} `json:"output_data" gorm:"type:jsonb;serializer:json"`
     CreatedAt     time.Time              `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 
     // Relations
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Attachment is a receipt photo or PDF attached to a transaction. The file
+// itself lives in the configured storage backend under StorageKey.
+type Attachment struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID        uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	TransactionID uuid.UUID `json:"transaction_id" gorm:"type:uuid;not null;index"`
+	FileName      string    `json:"file_name" gorm:"type:varchar(255);not null"`
+	ContentType   string    `json:"content_type" gorm:"type:varchar(100);not null"`
+	Size          int64     `json:"size" gorm:"not null"`
+	StorageKey    string    `json:"-" gorm:"type:varchar(255);not null;uniqueIndex"`
+	ThumbnailKey  *string   `json:"-" gorm:"type:varchar(255)"`
+	CreatedAt     time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Relations
+	User        User        `json:"-" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+	Transaction Transaction `json:"-" gorm:"foreignKey:TransactionID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (Attachment) TableName() string {
+	return "attachments"
+}
+
+func (a *Attachment) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BudgetAlert records that a budget crossed a usage threshold (in percent)
+// in the period starting at PeriodStart. The unique (budget_id,
+// period_start, threshold) index guarantees each alert fires only once.
+type BudgetAlert struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	BudgetID    uuid.UUID `json:"budget_id" gorm:"type:uuid;not null"`
+	PeriodStart time.Time `json:"period_start" gorm:"type:date;not null"`
+	Threshold   int       `json:"threshold" gorm:"not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Relations
+	Budget UserBudget `json:"-" gorm:"foreignKey:BudgetID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (BudgetAlert) TableName() string {
+	return "budget_alerts"
+}
+
+func (a *BudgetAlert) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DigestDelivery records that the digest for a user and period was queued,
+// so re-running the digest job never sends it twice.
+type DigestDelivery struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID      uuid.UUID  `json:"user_id" gorm:"type:uuid;not null"`
+	PeriodType  PeriodType `json:"period_type" gorm:"type:period_type_enum;not null"`
+	PeriodStart time.Time  `json:"period_start" gorm:"type:date;not null"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Relations
+	User User `json:"-" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (DigestDelivery) TableName() string {
+	return "digest_deliveries"
+}
+
+func (d *DigestDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
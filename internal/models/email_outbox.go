@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EmailOutbox is an email queued for delivery. Rows are written in the same
+// database transaction as the data they notify about and delivered by the
+// outbox job, which retries with exponential backoff and dead-letters
+// messages that keep failing.
+type EmailOutbox struct {
+	ID            uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ToAddress     string            `json:"to_address" gorm:"type:varchar(255);not null"`
+	Subject       string            `json:"subject" gorm:"type:varchar(255);not null"`
+	Body          string            `json:"-" gorm:"type:text;not null"`
+	TextBody      string            `json:"-" gorm:"type:text;not null;default:''"`
+	Status        EmailOutboxStatus `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	Attempts      int               `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt time.Time         `json:"next_attempt_at" gorm:"not null"`
+	LockedUntil   *time.Time        `json:"-" gorm:""`
+	LastError     *string           `json:"last_error" gorm:"type:text"`
+	SentAt        *time.Time        `json:"sent_at" gorm:""`
+	CreatedAt     time.Time         `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt     time.Time         `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (EmailOutbox) TableName() string {
+	return "email_outbox"
+}
+
+func (e *EmailOutbox) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	if e.Status == "" {
+		e.Status = EmailOutboxPending
+	}
+	if e.NextAttemptAt.IsZero() {
+		e.NextAttemptAt = time.Now()
+	}
+	return nil
+}
@@ -29,4 +29,30 @@ type TokenType string
 const (
 	TokenTypeEmailVerification TokenType = "email_verification"
 	TokenTypePasswordReset TokenType = "password_reset"
-)
\ No newline at end of file
+)
+
+type GoalStatus string
+
+const (
+	GoalStatusAchieved   GoalStatus = "achieved"
+	GoalStatusOnTrack    GoalStatus = "on_track"
+	GoalStatusBehind     GoalStatus = "behind"
+	GoalStatusOverdue    GoalStatus = "overdue"
+	GoalStatusNoActivity GoalStatus = "no_activity"
+)
+
+type EmailOutboxStatus string
+
+const (
+	EmailOutboxPending EmailOutboxStatus = "pending"
+	EmailOutboxSending EmailOutboxStatus = "sending"
+	EmailOutboxSent    EmailOutboxStatus = "sent"
+	EmailOutboxDead    EmailOutboxStatus = "dead"
+)
+
+type NotificationType string
+
+const (
+	NotificationBudgetThreshold NotificationType = "budget_threshold"
+	NotificationImportFinished  NotificationType = "import_finished"
+)
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Notification is an in-app message for a user. Data carries type specific
+// fields (e.g. budget_id and threshold) for the client to deep-link.
+type Notification struct {
+	ID        uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID        `json:"user_id" gorm:"type:uuid;not null"`
+	Type      NotificationType `json:"type" gorm:"type:varchar(50);not null"`
+	Title     string           `json:"title" gorm:"type:varchar(255);not null"`
+	Body      string           `json:"body" gorm:"type:text;not null"`
+	Data      map[string]any   `json:"data" gorm:"type:jsonb;serializer:json"`
+	ReadAt    *time.Time       `json:"read_at" gorm:""`
+	CreatedAt time.Time        `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Relations
+	User User `json:"-" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+func (n *Notification) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return nil
+}
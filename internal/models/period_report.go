@@ -17,7 +17,7 @@ type PeriodReport struct {
     TotalIncome     float64   `json:"total_income" gorm:"type:decimal(15,2);default:0"`
     TotalExpense    float64   `json:"total_expense" gorm:"type:decimal(15,2);default:0"`
     NetFlow         float64   `json:"net_flow" gorm:"type:decimal(15,2);default:0"`
-    ReportData      map[string]interface{} `json:"report_data" gorm:"type:jsonb"`
+    ReportData      map[string]interface{} `json:"report_data" gorm:"type:jsonb;serializer:json"`
     GeneratedAt     time.Time `json:"generated_at" gorm:"default:CURRENT_TIMESTAMP"`
     CreatedAt       time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
     UpdatedAt       time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
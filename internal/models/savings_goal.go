@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SavingsGoal struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID       uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	CategoryID   *uuid.UUID `json:"category_id" gorm:"type:uuid;index"`
+	Name         string     `json:"name" gorm:"type:varchar(100);not null"`
+	TargetAmount float64    `json:"target_amount" gorm:"type:decimal(15,2);not null"`
+	StartDate    time.Time  `json:"start_date" gorm:"type:date;not null"`
+	Deadline     time.Time  `json:"deadline" gorm:"type:date;not null"`
+	Description  *string    `json:"description" gorm:"type:text"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Relations
+	User     User      `json:"-" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
+	Category *Category `json:"category,omitempty" gorm:"foreignKey:CategoryID;references:ID;constraint:OnDelete:SET NULL"`
+}
+
+func (SavingsGoal) TableName() string {
+	return "savings_goals"
+}
+
+func (g *SavingsGoal) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return nil
+}
@@ -21,6 +21,8 @@ type Transaction struct {
     // Relations
     User     User     `json:"user" gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE"`
     Category Category `json:"category" gorm:"foreignKey:CategoryID;references:ID;constraint:OnDelete:CASCADE"`
+    Splits   []TransactionSplit `json:"splits,omitempty" gorm:"foreignKey:TransactionID;references:ID;constraint:OnDelete:CASCADE"`
+    Tags     []Tag    `json:"tags,omitempty" gorm:"many2many:transaction_tags;constraint:OnDelete:CASCADE"`
 }
 
 func (Transaction) TableName() string {
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TransactionSplit is a child line of a Transaction booked to its own
+// category. When a transaction has splits their amounts always sum to the
+// parent amount and they replace the parent category in aggregations.
+type TransactionSplit struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TransactionID uuid.UUID `json:"transaction_id" gorm:"type:uuid;not null;index"`
+	CategoryID    uuid.UUID `json:"category_id" gorm:"type:uuid;not null;index"`
+	Amount        float64   `json:"amount" gorm:"type:decimal(15,2);not null"`
+	Description   *string   `json:"description" gorm:"type:text"`
+	CreatedAt     time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Relations
+	Category Category `json:"category" gorm:"foreignKey:CategoryID;references:ID;constraint:OnDelete:CASCADE"`
+}
+
+func (TransactionSplit) TableName() string {
+	return "transaction_splits"
+}
+
+func (s *TransactionSplit) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
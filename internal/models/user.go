@@ -14,6 +14,9 @@ type User struct {
 	Password  string    `json:"password" gorm:"type:varchar(255);not null"`
 	IsEmailVerified bool       `json:"is_email_verified" gorm:"default:false;not null"`
 	EmailVerifiedAt *time.Time `json:"email_verified_at" gorm:""`
+	Locale    string    `json:"locale" gorm:"type:varchar(10);not null;default:'en'"`
+	DigestWeekly  bool  `json:"digest_weekly" gorm:"default:false;not null"`
+	DigestMonthly bool  `json:"digest_monthly" gorm:"default:false;not null"`
 	CreatedAt time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
 }
@@ -0,0 +1,109 @@
+// Package realtime fans notification events out to connected clients. Events
+// are published with Postgres NOTIFY so that every API instance, each
+// running a Listener, delivers them to its own subscribers.
+package realtime
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// subscriberBuffer is how many events a slow client may lag behind before
+// new events are dropped for it.
+const subscriberBuffer = 16
+
+// Event is a message pushed to a user's open streams.
+type Event struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Hub keeps the open streams of this instance per user.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[uuid.UUID]map[chan Event]struct{}
+	closed      bool
+}
+
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[uuid.UUID]map[chan Event]struct{})}
+}
+
+// Subscribe registers a stream for the user. The returned function must be
+// called when the stream ends; it closes the channel. After Close the
+// channel is returned already closed.
+func (h *Hub) Subscribe(userID uuid.UUID) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		close(ch)
+		return ch, func() {}
+	}
+	streams, ok := h.subscribers[userID]
+	if !ok {
+		streams = make(map[chan Event]struct{})
+		h.subscribers[userID] = streams
+	}
+	streams[ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		// Close may already have closed and removed the channel.
+		if _, ok := h.subscribers[userID][ch]; !ok {
+			return
+		}
+		delete(h.subscribers[userID], ch)
+		if len(h.subscribers[userID]) == 0 {
+			delete(h.subscribers, userID)
+		}
+		close(ch)
+	}
+}
+
+// Close ends every open stream by closing its channel, so long-lived SSE
+// responses finish and the HTTP server can drain.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for userID, streams := range h.subscribers {
+		for ch := range streams {
+			close(ch)
+		}
+		delete(h.subscribers, userID)
+	}
+}
+
+// Publish delivers the event to the user's streams on this instance without
+// blocking; a stream whose buffer is full misses the event.
+func (h *Hub) Publish(userID uuid.UUID, event Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subscribers[userID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribers returns the number of open streams on this instance.
+func (h *Hub) Subscribers() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	total := 0
+	for _, streams := range h.subscribers {
+		total += len(streams)
+	}
+	return total
+}
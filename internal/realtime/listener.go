@@ -0,0 +1,110 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+// Channel is the Postgres NOTIFY channel carrying user events.
+const Channel = "user_events"
+
+// maxPayload stays below Postgres' 8000 byte NOTIFY payload limit.
+const maxPayload = 7900
+
+type envelope struct {
+	UserID uuid.UUID `json:"user_id"`
+	Event  Event     `json:"event"`
+}
+
+// NotifyTx publishes an event for the user through db. Inside a transaction
+// Postgres delivers it only on commit, so clients never see events for
+// rolled back writes. Events whose data exceed the NOTIFY payload limit are
+// sent without data; clients refetch.
+func NotifyTx(db *gorm.DB, userID uuid.UUID, eventType string, data any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	payload, err := json.Marshal(envelope{UserID: userID, Event: Event{Type: eventType, Data: raw}})
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	if len(payload) > maxPayload {
+		payload, _ = json.Marshal(envelope{UserID: userID, Event: Event{Type: eventType, Data: json.RawMessage("null")}})
+	}
+
+	return db.Exec("SELECT pg_notify(?, ?)", Channel, string(payload)).Error
+}
+
+// Listener holds a dedicated connection that LISTENs on Channel and feeds
+// received events into the hub.
+type Listener struct {
+	dsn string
+	hub *Hub
+}
+
+func NewListener(dsn string, hub *Hub) *Listener {
+	return &Listener{dsn: dsn, hub: hub}
+}
+
+// Run listens until ctx is cancelled, reconnecting with backoff when the
+// connection drops. Events sent while disconnected are lost; clients resync
+// through GET /notifications.
+func (l *Listener) Run(ctx context.Context) {
+	backoff := time.Second
+	for {
+		connected, err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = time.Second
+		}
+		slog.WarnContext(ctx, "realtime listener disconnected", "retry_in", backoff.String(), "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+// listen reports whether LISTEN succeeded, so Run can reset its backoff.
+func (l *Listener) listen(ctx context.Context) (bool, error) {
+	conn, err := pgx.Connect(ctx, l.dsn)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{Channel}.Sanitize()); err != nil {
+		return false, err
+	}
+	slog.InfoContext(ctx, "realtime listener connected", "channel", Channel)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+
+		var message envelope
+		if err := json.Unmarshal([]byte(notification.Payload), &message); err != nil {
+			slog.WarnContext(ctx, "dropping malformed realtime event", "error", err)
+			continue
+		}
+		l.hub.Publish(message.UserID, message.Event)
+	}
+}
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type AILogRepository interface {
+	Create(ctx context.Context, log *models.AILog) error
+}
+
+type aiLogRepository struct {
+	DB *gorm.DB
+}
+
+func NewAILogRepository(db *gorm.DB) AILogRepository {
+	return &aiLogRepository{DB: db}
+}
+
+func (r *aiLogRepository) Create(ctx context.Context, log *models.AILog) error {
+	return conn(ctx, r.DB).Model(&models.AILog{}).Create(log).Error
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"gin-backend-app/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *models.Attachment) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Attachment, error)
+	ListByTransaction(ctx context.Context, transactionId, userId uuid.UUID) ([]*models.Attachment, error)
+	ListByUser(ctx context.Context, userId uuid.UUID) ([]*models.Attachment, error)
+	CountByTransaction(ctx context.Context, transactionId uuid.UUID) (int64, error)
+	Delete(ctx context.Context, id, userId uuid.UUID) error
+}
+
+type attachmentRepository struct {
+	DB *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{DB: db}
+}
+
+func (r *attachmentRepository) Create(ctx context.Context, attachment *models.Attachment) error {
+	return conn(ctx, r.DB).Model(&models.Attachment{}).Create(attachment).Error
+}
+
+func (r *attachmentRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := conn(ctx, r.DB).Model(&models.Attachment{}).Where("id = ?", id).First(&attachment).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (r *attachmentRepository) ListByTransaction(ctx context.Context, transactionId, userId uuid.UUID) ([]*models.Attachment, error) {
+	var attachments []*models.Attachment
+	err := conn(ctx, r.DB).Model(&models.Attachment{}).
+		Where("transaction_id = ? AND user_id = ?", transactionId, userId).
+		Order("created_at ASC").
+		Find(&attachments).Error
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepository) ListByUser(ctx context.Context, userId uuid.UUID) ([]*models.Attachment, error) {
+	var attachments []*models.Attachment
+	err := conn(ctx, r.DB).Model(&models.Attachment{}).
+		Where("user_id = ?", userId).
+		Find(&attachments).Error
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepository) CountByTransaction(ctx context.Context, transactionId uuid.UUID) (int64, error) {
+	var count int64
+	err := conn(ctx, r.DB).Model(&models.Attachment{}).Where("transaction_id = ?", transactionId).Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *attachmentRepository) Delete(ctx context.Context, id, userId uuid.UUID) error {
+	tx := conn(ctx, r.DB).Delete(&models.Attachment{}, "id = ? AND user_id = ?", id, userId)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BudgetAlertRepository interface {
+	CreateIfAbsent(ctx context.Context, alert *models.BudgetAlert) (bool, error)
+}
+
+type budgetAlertRepository struct {
+	DB *gorm.DB
+}
+
+func NewBudgetAlertRepository(db *gorm.DB) BudgetAlertRepository {
+	return &budgetAlertRepository{DB: db}
+}
+
+// CreateIfAbsent inserts the alert unless one already exists for the same
+// budget, period and threshold. It reports whether a row was inserted.
+func (r *budgetAlertRepository) CreateIfAbsent(ctx context.Context, alert *models.BudgetAlert) (bool, error) {
+	tx := conn(ctx, r.DB).Model(&models.BudgetAlert{}).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "budget_id"}, {Name: "period_start"}, {Name: "threshold"}},
+			DoNothing: true,
+		}).
+		Create(alert)
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	return tx.RowsAffected > 0, nil
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"gin-backend-app/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CategoryRepository interface {
+	FindByID(ctx context.Context, id, userId uuid.UUID) (*models.Category, error)
+	FindByName(ctx context.Context, userId uuid.UUID, name string, groupType models.TransactionGroupType) (*models.Category, error)
+	ListByUser(ctx context.Context, userId uuid.UUID) ([]*models.Category, error)
+}
+
+type categoryRepository struct {
+	DB *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) CategoryRepository {
+	return &categoryRepository{DB: db}
+}
+
+func (r *categoryRepository) FindByID(ctx context.Context, id, userId uuid.UUID) (*models.Category, error) {
+	var category models.Category
+	err := conn(ctx, r.DB).Model(&models.Category{}).Where("id = ? AND user_id = ?", id, userId).First(&category).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (r *categoryRepository) FindByName(ctx context.Context, userId uuid.UUID, name string, groupType models.TransactionGroupType) (*models.Category, error) {
+	var category models.Category
+	err := conn(ctx, r.DB).Model(&models.Category{}).
+		Where("user_id = ? AND LOWER(name) = LOWER(?) AND group_type = ?", userId, name, groupType).
+		First(&category).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (r *categoryRepository) ListByUser(ctx context.Context, userId uuid.UUID) ([]*models.Category, error) {
+	var categories []*models.Category
+	err := conn(ctx, r.DB).Model(&models.Category{}).Where("user_id = ?", userId).Order("name ASC").Find(&categories).Error
+	if err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DigestDeliveryRepository interface {
+	Exists(ctx context.Context, userId uuid.UUID, periodType models.PeriodType, periodStart time.Time) (bool, error)
+	CreateIfAbsent(ctx context.Context, delivery *models.DigestDelivery) (bool, error)
+}
+
+type digestDeliveryRepository struct {
+	DB *gorm.DB
+}
+
+func NewDigestDeliveryRepository(db *gorm.DB) DigestDeliveryRepository {
+	return &digestDeliveryRepository{DB: db}
+}
+
+func (r *digestDeliveryRepository) Exists(ctx context.Context, userId uuid.UUID, periodType models.PeriodType, periodStart time.Time) (bool, error) {
+	var count int64
+	err := conn(ctx, r.DB).Model(&models.DigestDelivery{}).
+		Where("user_id = ? AND period_type = ? AND period_start = ?", userId, periodType, periodStart).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CreateIfAbsent inserts the delivery unless one exists for the same user
+// and period. It reports whether a row was inserted.
+func (r *digestDeliveryRepository) CreateIfAbsent(ctx context.Context, delivery *models.DigestDelivery) (bool, error) {
+	tx := conn(ctx, r.DB).Model(&models.DigestDelivery{}).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "period_type"}, {Name: "period_start"}},
+			DoNothing: true,
+		}).
+		Create(delivery)
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	return tx.RowsAffected > 0, nil
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EmailOutboxRepository interface {
+	Create(ctx context.Context, message *models.EmailOutbox) error
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*models.EmailOutbox, error)
+	RenewLease(ctx context.Context, message *models.EmailOutbox, lockedUntil time.Time) (bool, error)
+	MarkSent(ctx context.Context, id uuid.UUID, attempts int) error
+	MarkFailed(ctx context.Context, id uuid.UUID, attempts int, nextAttemptAt time.Time, lastError string, dead bool) error
+	DeleteSentBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type emailOutboxRepository struct {
+	DB *gorm.DB
+}
+
+func NewEmailOutboxRepository(db *gorm.DB) EmailOutboxRepository {
+	return &emailOutboxRepository{DB: db}
+}
+
+func (r *emailOutboxRepository) Create(ctx context.Context, message *models.EmailOutbox) error {
+	return conn(ctx, r.DB).Model(&models.EmailOutbox{}).Create(message).Error
+}
+
+// ClaimDue locks up to limit messages that are due for delivery and leases
+// them until now+lease; the returned messages carry that lease in
+// LockedUntil. Messages left in "sending" by a crashed instance are
+// claimable again once their lease expires. SKIP LOCKED lets several
+// replicas drain the outbox concurrently without sending twice.
+func (r *emailOutboxRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*models.EmailOutbox, error) {
+	var messages []*models.EmailOutbox
+	// Postgres keeps microseconds; truncating lets RenewLease compare the
+	// stored value exactly.
+	lockedUntil := now.Add(lease).Truncate(time.Microsecond)
+
+	err := conn(ctx, r.DB).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.EmailOutbox{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("(status = ? AND next_attempt_at <= ?) OR (status = ? AND locked_until < ?)",
+				models.EmailOutboxPending, now, models.EmailOutboxSending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Find(&messages).Error
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, 0, len(messages))
+		for _, message := range messages {
+			ids = append(ids, message.ID)
+		}
+
+		return tx.Model(&models.EmailOutbox{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":       models.EmailOutboxSending,
+				"locked_until": lockedUntil,
+				"updated_at":   now,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, message := range messages {
+		message.Status = models.EmailOutboxSending
+		message.LockedUntil = &lockedUntil
+	}
+	return messages, nil
+}
+
+// RenewLease extends the lease on a claimed message to lockedUntil. It
+// reports false, leaving the row alone, when the lease held in
+// message.LockedUntil has been taken over by another sender.
+func (r *emailOutboxRepository) RenewLease(ctx context.Context, message *models.EmailOutbox, lockedUntil time.Time) (bool, error) {
+	lockedUntil = lockedUntil.Truncate(time.Microsecond)
+	tx := conn(ctx, r.DB).Model(&models.EmailOutbox{}).
+		Where("id = ? AND status = ? AND locked_until = ?", message.ID, models.EmailOutboxSending, message.LockedUntil).
+		Updates(map[string]interface{}{
+			"locked_until": lockedUntil,
+			"updated_at":   time.Now(),
+		})
+	if tx.Error != nil || tx.RowsAffected == 0 {
+		return false, tx.Error
+	}
+
+	message.LockedUntil = &lockedUntil
+	return true, nil
+}
+
+func (r *emailOutboxRepository) MarkSent(ctx context.Context, id uuid.UUID, attempts int) error {
+	now := time.Now()
+	return conn(ctx, r.DB).Model(&models.EmailOutbox{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       models.EmailOutboxSent,
+		"attempts":     attempts,
+		"sent_at":      now,
+		"locked_until": nil,
+		"last_error":   nil,
+		"updated_at":   now,
+	}).Error
+}
+
+// MarkFailed records a failed attempt and either reschedules the message or,
+// when dead is set, moves it to the dead-letter state.
+func (r *emailOutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, attempts int, nextAttemptAt time.Time, lastError string, dead bool) error {
+	status := models.EmailOutboxPending
+	if dead {
+		status = models.EmailOutboxDead
+	}
+
+	return conn(ctx, r.DB).Model(&models.EmailOutbox{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":          status,
+		"attempts":        attempts,
+		"next_attempt_at": nextAttemptAt,
+		"locked_until":    nil,
+		"last_error":      lastError,
+		"updated_at":      time.Now(),
+	}).Error
+}
+
+func (r *emailOutboxRepository) DeleteSentBefore(ctx context.Context, before time.Time) (int64, error) {
+	tx := conn(ctx, r.DB).Where("status = ? AND sent_at < ?", models.EmailOutboxSent, before).Delete(&models.EmailOutbox{})
+	if tx.Error != nil {
+		return 0, tx.Error
+	}
+	return tx.RowsAffected, nil
+}
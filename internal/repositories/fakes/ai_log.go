@@ -0,0 +1,44 @@
+package fakes
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var _ repositories.AILogRepository = (*AILogRepository)(nil)
+
+type AILogRepository struct {
+	mu   sync.Mutex
+	logs []models.AILog
+}
+
+func NewAILogRepository() *AILogRepository {
+	return &AILogRepository{}
+}
+
+// Logs returns copies of all stored logs in creation order.
+func (r *AILogRepository) Logs() []models.AILog {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]models.AILog(nil), r.logs...)
+}
+
+func (r *AILogRepository) Create(ctx context.Context, log *models.AILog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if log.ID == uuid.Nil {
+		log.ID = uuid.New()
+	}
+	if log.CreatedAt.IsZero() {
+		log.CreatedAt = time.Now()
+	}
+	r.logs = append(r.logs, *log)
+	return nil
+}
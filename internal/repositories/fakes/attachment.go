@@ -0,0 +1,97 @@
+package fakes
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var _ repositories.AttachmentRepository = (*AttachmentRepository)(nil)
+
+type AttachmentRepository struct {
+	mu          sync.Mutex
+	attachments map[uuid.UUID]models.Attachment
+}
+
+func NewAttachmentRepository() *AttachmentRepository {
+	return &AttachmentRepository{attachments: map[uuid.UUID]models.Attachment{}}
+}
+
+func (r *AttachmentRepository) Create(ctx context.Context, attachment *models.Attachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.attachments {
+		if existing.StorageKey == attachment.StorageKey {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	if attachment.ID == uuid.Nil {
+		attachment.ID = uuid.New()
+	}
+	if attachment.CreatedAt.IsZero() {
+		attachment.CreatedAt = time.Now()
+	}
+	r.attachments[attachment.ID] = *attachment
+	return nil
+}
+
+func (r *AttachmentRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Attachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attachment, ok := r.attachments[id]
+	if !ok {
+		return nil, nil
+	}
+	return &attachment, nil
+}
+
+func (r *AttachmentRepository) ListByTransaction(ctx context.Context, transactionId, userId uuid.UUID) ([]*models.Attachment, error) {
+	return r.list(func(attachment models.Attachment) bool {
+		return attachment.TransactionID == transactionId && attachment.UserID == userId
+	}), nil
+}
+
+func (r *AttachmentRepository) ListByUser(ctx context.Context, userId uuid.UUID) ([]*models.Attachment, error) {
+	return r.list(func(attachment models.Attachment) bool { return attachment.UserID == userId }), nil
+}
+
+func (r *AttachmentRepository) CountByTransaction(ctx context.Context, transactionId uuid.UUID) (int64, error) {
+	attachments := r.list(func(attachment models.Attachment) bool { return attachment.TransactionID == transactionId })
+	return int64(len(attachments)), nil
+}
+
+func (r *AttachmentRepository) Delete(ctx context.Context, id, userId uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attachment, ok := r.attachments[id]
+	if !ok || attachment.UserID != userId {
+		return gorm.ErrRecordNotFound
+	}
+	delete(r.attachments, id)
+	return nil
+}
+
+// list returns copies of the attachments matching keep, oldest first.
+func (r *AttachmentRepository) list(keep func(models.Attachment) bool) []*models.Attachment {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attachments := []*models.Attachment{}
+	for _, attachment := range r.attachments {
+		if keep(attachment) {
+			attachment := attachment
+			attachments = append(attachments, &attachment)
+		}
+	}
+	sort.Slice(attachments, func(i, j int) bool { return attachments[i].CreatedAt.Before(attachments[j].CreatedAt) })
+	return attachments
+}
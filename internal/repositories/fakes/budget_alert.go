@@ -0,0 +1,49 @@
+package fakes
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var _ repositories.BudgetAlertRepository = (*BudgetAlertRepository)(nil)
+
+type BudgetAlertRepository struct {
+	mu     sync.Mutex
+	alerts []models.BudgetAlert
+}
+
+func NewBudgetAlertRepository() *BudgetAlertRepository {
+	return &BudgetAlertRepository{}
+}
+
+// Alerts returns copies of all stored alerts in creation order.
+func (r *BudgetAlertRepository) Alerts() []models.BudgetAlert {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]models.BudgetAlert(nil), r.alerts...)
+}
+
+func (r *BudgetAlertRepository) CreateIfAbsent(ctx context.Context, alert *models.BudgetAlert) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.alerts {
+		if existing.BudgetID == alert.BudgetID && existing.PeriodStart.Equal(alert.PeriodStart) && existing.Threshold == alert.Threshold {
+			return false, nil
+		}
+	}
+	if alert.ID == uuid.Nil {
+		alert.ID = uuid.New()
+	}
+	if alert.CreatedAt.IsZero() {
+		alert.CreatedAt = time.Now()
+	}
+	r.alerts = append(r.alerts, *alert)
+	return true, nil
+}
@@ -0,0 +1,90 @@
+package fakes
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var _ repositories.CategoryRepository = (*CategoryRepository)(nil)
+
+type CategoryRepository struct {
+	mu         sync.Mutex
+	categories map[uuid.UUID]models.Category
+}
+
+func NewCategoryRepository(categories ...*models.Category) *CategoryRepository {
+	r := &CategoryRepository{categories: map[uuid.UUID]models.Category{}}
+	for _, category := range categories {
+		r.Add(category)
+	}
+	return r
+}
+
+// Add stores category, assigning an id when it has none. No service creates
+// categories, so the interface has no Create.
+func (r *CategoryRepository) Add(category *models.Category) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if category.ID == uuid.Nil {
+		category.ID = uuid.New()
+	}
+	now := time.Now()
+	if category.CreatedAt.IsZero() {
+		category.CreatedAt = now
+	}
+	category.UpdatedAt = now
+	r.categories[category.ID] = *category
+}
+
+func (r *CategoryRepository) FindByID(ctx context.Context, id, userId uuid.UUID) (*models.Category, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	category, ok := r.categories[id]
+	if !ok || category.UserID != userId {
+		return nil, nil
+	}
+	return &category, nil
+}
+
+func (r *CategoryRepository) FindByName(ctx context.Context, userId uuid.UUID, name string, groupType models.TransactionGroupType) (*models.Category, error) {
+	categories, _ := r.ListByUser(ctx, userId)
+	for _, category := range categories {
+		if strings.EqualFold(category.Name, name) && category.GroupType == groupType {
+			return category, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *CategoryRepository) ListByUser(ctx context.Context, userId uuid.UUID) ([]*models.Category, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	categories := []*models.Category{}
+	for _, category := range r.categories {
+		if category.UserID == userId {
+			category := category
+			categories = append(categories, &category)
+		}
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+	return categories, nil
+}
+
+// get returns the stored category regardless of its owner.
+func (r *CategoryRepository) get(id uuid.UUID) (models.Category, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	category, ok := r.categories[id]
+	return category, ok
+}
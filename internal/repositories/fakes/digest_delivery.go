@@ -0,0 +1,57 @@
+package fakes
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var _ repositories.DigestDeliveryRepository = (*DigestDeliveryRepository)(nil)
+
+type DigestDeliveryRepository struct {
+	mu         sync.Mutex
+	deliveries []models.DigestDelivery
+}
+
+func NewDigestDeliveryRepository() *DigestDeliveryRepository {
+	return &DigestDeliveryRepository{}
+}
+
+func (r *DigestDeliveryRepository) Exists(ctx context.Context, userId uuid.UUID, periodType models.PeriodType, periodStart time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.exists(userId, periodType, periodStart), nil
+}
+
+func (r *DigestDeliveryRepository) CreateIfAbsent(ctx context.Context, delivery *models.DigestDelivery) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.exists(delivery.UserID, delivery.PeriodType, delivery.PeriodStart) {
+		return false, nil
+	}
+	if delivery.ID == uuid.Nil {
+		delivery.ID = uuid.New()
+	}
+	if delivery.CreatedAt.IsZero() {
+		delivery.CreatedAt = time.Now()
+	}
+	r.deliveries = append(r.deliveries, *delivery)
+	return true, nil
+}
+
+// exists mirrors the unique (user_id, period_type, period_start) index;
+// callers hold r.mu.
+func (r *DigestDeliveryRepository) exists(userId uuid.UUID, periodType models.PeriodType, periodStart time.Time) bool {
+	for _, delivery := range r.deliveries {
+		if delivery.UserID == userId && delivery.PeriodType == periodType && delivery.PeriodStart.Equal(periodStart) {
+			return true
+		}
+	}
+	return false
+}
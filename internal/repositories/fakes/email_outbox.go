@@ -0,0 +1,159 @@
+package fakes
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var _ repositories.EmailOutboxRepository = (*EmailOutboxRepository)(nil)
+
+type EmailOutboxRepository struct {
+	mu       sync.Mutex
+	messages []models.EmailOutbox
+}
+
+func NewEmailOutboxRepository() *EmailOutboxRepository {
+	return &EmailOutboxRepository{}
+}
+
+// Messages returns copies of all stored messages in creation order.
+func (r *EmailOutboxRepository) Messages() []models.EmailOutbox {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]models.EmailOutbox(nil), r.messages...)
+}
+
+func (r *EmailOutboxRepository) Create(ctx context.Context, message *models.EmailOutbox) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if message.ID == uuid.Nil {
+		message.ID = uuid.New()
+	}
+	if message.Status == "" {
+		message.Status = models.EmailOutboxPending
+	}
+	if message.NextAttemptAt.IsZero() {
+		message.NextAttemptAt = now
+	}
+	if message.CreatedAt.IsZero() {
+		message.CreatedAt = now
+	}
+	message.UpdatedAt = now
+	r.messages = append(r.messages, *message)
+	return nil
+}
+
+func (r *EmailOutboxRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*models.EmailOutbox, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []int
+	for i, message := range r.messages {
+		pending := message.Status == models.EmailOutboxPending && !message.NextAttemptAt.After(now)
+		expired := message.Status == models.EmailOutboxSending && message.LockedUntil != nil && message.LockedUntil.Before(now)
+		if pending || expired {
+			due = append(due, i)
+		}
+	}
+	sort.SliceStable(due, func(a, b int) bool {
+		return r.messages[due[a]].NextAttemptAt.Before(r.messages[due[b]].NextAttemptAt)
+	})
+	due = page(due, limit, 0)
+
+	lockedUntil := now.Add(lease).Truncate(time.Microsecond)
+	claimed := make([]*models.EmailOutbox, 0, len(due))
+	for _, i := range due {
+		r.messages[i].Status = models.EmailOutboxSending
+		r.messages[i].LockedUntil = &lockedUntil
+		r.messages[i].UpdatedAt = now
+		message := r.messages[i]
+		claimed = append(claimed, &message)
+	}
+	return claimed, nil
+}
+
+func (r *EmailOutboxRepository) RenewLease(ctx context.Context, message *models.EmailOutbox, lockedUntil time.Time) (bool, error) {
+	lockedUntil = lockedUntil.Truncate(time.Microsecond)
+	renewed := false
+	r.update(message.ID, func(stored *models.EmailOutbox) {
+		if stored.Status != models.EmailOutboxSending || stored.LockedUntil == nil || message.LockedUntil == nil || !stored.LockedUntil.Equal(*message.LockedUntil) {
+			return
+		}
+		stored.LockedUntil = &lockedUntil
+		stored.UpdatedAt = time.Now()
+		renewed = true
+	})
+	if renewed {
+		message.LockedUntil = &lockedUntil
+	}
+	return renewed, nil
+}
+
+func (r *EmailOutboxRepository) MarkSent(ctx context.Context, id uuid.UUID, attempts int) error {
+	now := time.Now()
+	r.update(id, func(message *models.EmailOutbox) {
+		message.Status = models.EmailOutboxSent
+		message.Attempts = attempts
+		message.SentAt = &now
+		message.LockedUntil = nil
+		message.LastError = nil
+		message.UpdatedAt = now
+	})
+	return nil
+}
+
+func (r *EmailOutboxRepository) MarkFailed(ctx context.Context, id uuid.UUID, attempts int, nextAttemptAt time.Time, lastError string, dead bool) error {
+	status := models.EmailOutboxPending
+	if dead {
+		status = models.EmailOutboxDead
+	}
+
+	r.update(id, func(message *models.EmailOutbox) {
+		message.Status = status
+		message.Attempts = attempts
+		message.NextAttemptAt = nextAttemptAt
+		message.LockedUntil = nil
+		message.LastError = &lastError
+		message.UpdatedAt = time.Now()
+	})
+	return nil
+}
+
+func (r *EmailOutboxRepository) DeleteSentBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.messages[:0]
+	for _, message := range r.messages {
+		if message.Status == models.EmailOutboxSent && message.SentAt != nil && message.SentAt.Before(before) {
+			continue
+		}
+		kept = append(kept, message)
+	}
+	deleted := int64(len(r.messages) - len(kept))
+	r.messages = kept
+	return deleted, nil
+}
+
+// update applies fn to the stored message; like the gorm repository,
+// updating a missing message is not an error.
+func (r *EmailOutboxRepository) update(id uuid.UUID, fn func(*models.EmailOutbox)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.messages {
+		if r.messages[i].ID == id {
+			fn(&r.messages[i])
+			return
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package fakes
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var _ repositories.NotificationRepository = (*NotificationRepository)(nil)
+
+// Event is a realtime event recorded by NotificationRepository.Publish.
+type Event struct {
+	UserID uuid.UUID
+	Type   string
+	Data   any
+}
+
+type NotificationRepository struct {
+	mu            sync.Mutex
+	notifications map[uuid.UUID]models.Notification
+	events        []Event
+}
+
+func NewNotificationRepository() *NotificationRepository {
+	return &NotificationRepository{notifications: map[uuid.UUID]models.Notification{}}
+}
+
+// Events returns the published events in order. The fake has no
+// transactions, so events are recorded immediately rather than on commit.
+func (r *NotificationRepository) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Event(nil), r.events...)
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if notification.ID == uuid.Nil {
+		notification.ID = uuid.New()
+	}
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+	r.notifications[notification.ID] = *notification
+	return nil
+}
+
+func (r *NotificationRepository) List(ctx context.Context, userId uuid.UUID, unreadOnly bool, limit, offset int) ([]*models.Notification, int64, error) {
+	notifications := r.list(func(notification models.Notification) bool {
+		return notification.UserID == userId && (!unreadOnly || notification.ReadAt == nil)
+	})
+	return page(notifications, limit, offset), int64(len(notifications)), nil
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userId uuid.UUID) (int64, error) {
+	notifications := r.list(func(notification models.Notification) bool {
+		return notification.UserID == userId && notification.ReadAt == nil
+	})
+	return int64(len(notifications)), nil
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, id, userId uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	notification, ok := r.notifications[id]
+	if !ok || notification.UserID != userId {
+		return gorm.ErrRecordNotFound
+	}
+	if notification.ReadAt == nil {
+		notification.ReadAt = &at
+		r.notifications[id] = notification
+	}
+	return nil
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userId uuid.UUID, at time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var marked int64
+	for id, notification := range r.notifications {
+		if notification.UserID == userId && notification.ReadAt == nil {
+			notification.ReadAt = &at
+			r.notifications[id] = notification
+			marked++
+		}
+	}
+	return marked, nil
+}
+
+func (r *NotificationRepository) Publish(ctx context.Context, userId uuid.UUID, eventType string, data any) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, Event{UserID: userId, Type: eventType, Data: data})
+	return nil
+}
+
+// list returns copies of the notifications matching keep, newest first.
+func (r *NotificationRepository) list(keep func(models.Notification) bool) []*models.Notification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	notifications := []*models.Notification{}
+	for _, notification := range r.notifications {
+		if keep(notification) {
+			notification := notification
+			notifications = append(notifications, &notification)
+		}
+	}
+	sort.Slice(notifications, func(i, j int) bool {
+		return notifications[i].CreatedAt.After(notifications[j].CreatedAt)
+	})
+	return notifications
+}
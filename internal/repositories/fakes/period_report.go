@@ -0,0 +1,67 @@
+package fakes
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var _ repositories.PeriodReportRepository = (*PeriodReportRepository)(nil)
+
+type PeriodReportRepository struct {
+	mu      sync.Mutex
+	reports []models.PeriodReport
+}
+
+func NewPeriodReportRepository() *PeriodReportRepository {
+	return &PeriodReportRepository{}
+}
+
+func (r *PeriodReportRepository) FindByPeriod(ctx context.Context, userId uuid.UUID, periodType models.PeriodType, periodStart time.Time) (*models.PeriodReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if i := r.index(userId, periodType, periodStart); i >= 0 {
+		report := r.reports[i]
+		return &report, nil
+	}
+	return nil, nil
+}
+
+func (r *PeriodReportRepository) Save(ctx context.Context, report *models.PeriodReport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	report.UpdatedAt = now
+	if i := r.index(report.UserID, report.PeriodType, report.PeriodStart); i >= 0 {
+		report.ID = r.reports[i].ID
+		report.CreatedAt = r.reports[i].CreatedAt
+		r.reports[i] = *report
+		return nil
+	}
+
+	if report.ID == uuid.Nil {
+		report.ID = uuid.New()
+	}
+	if report.CreatedAt.IsZero() {
+		report.CreatedAt = now
+	}
+	r.reports = append(r.reports, *report)
+	return nil
+}
+
+// index returns the position of the report for the period, or -1; callers
+// hold r.mu.
+func (r *PeriodReportRepository) index(userId uuid.UUID, periodType models.PeriodType, periodStart time.Time) int {
+	for i, report := range r.reports {
+		if report.UserID == userId && report.PeriodType == periodType && report.PeriodStart.Equal(periodStart) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,117 @@
+package fakes
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var _ repositories.SavingsGoalRepository = (*SavingsGoalRepository)(nil)
+
+// SavingsGoalRepository stores goals in memory. Categories is used to fill in
+// preloaded categories; it may be nil.
+type SavingsGoalRepository struct {
+	Categories *CategoryRepository
+
+	mu    sync.Mutex
+	goals map[uuid.UUID]models.SavingsGoal
+}
+
+func NewSavingsGoalRepository(categories *CategoryRepository) *SavingsGoalRepository {
+	return &SavingsGoalRepository{Categories: categories, goals: map[uuid.UUID]models.SavingsGoal{}}
+}
+
+func (r *SavingsGoalRepository) Create(ctx context.Context, goal *models.SavingsGoal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if goal.ID == uuid.Nil {
+		goal.ID = uuid.New()
+	}
+	now := time.Now()
+	if goal.CreatedAt.IsZero() {
+		goal.CreatedAt = now
+	}
+	goal.UpdatedAt = now
+
+	stored := *goal
+	stored.Category = nil
+	r.goals[stored.ID] = stored
+	return nil
+}
+
+func (r *SavingsGoalRepository) FindByID(ctx context.Context, id, userId uuid.UUID) (*models.SavingsGoal, error) {
+	r.mu.Lock()
+	goal, ok := r.goals[id]
+	r.mu.Unlock()
+
+	if !ok || goal.UserID != userId {
+		return nil, nil
+	}
+	return r.preload(goal), nil
+}
+
+func (r *SavingsGoalRepository) ListByUser(ctx context.Context, userId uuid.UUID) ([]*models.SavingsGoal, error) {
+	r.mu.Lock()
+	var stored []models.SavingsGoal
+	for _, goal := range r.goals {
+		if goal.UserID == userId {
+			stored = append(stored, goal)
+		}
+	}
+	r.mu.Unlock()
+
+	goals := make([]*models.SavingsGoal, 0, len(stored))
+	for _, goal := range stored {
+		goals = append(goals, r.preload(goal))
+	}
+	sort.Slice(goals, func(i, j int) bool { return goals[i].Deadline.Before(goals[j].Deadline) })
+	return goals, nil
+}
+
+func (r *SavingsGoalRepository) Update(ctx context.Context, goal *models.SavingsGoal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.goals[goal.ID]
+	if !ok {
+		return nil
+	}
+	stored.Name = goal.Name
+	stored.TargetAmount = goal.TargetAmount
+	stored.CategoryID = goal.CategoryID
+	stored.StartDate = goal.StartDate
+	stored.Deadline = goal.Deadline
+	stored.Description = goal.Description
+	stored.UpdatedAt = time.Now()
+	r.goals[stored.ID] = stored
+	return nil
+}
+
+func (r *SavingsGoalRepository) Delete(ctx context.Context, id, userId uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	goal, ok := r.goals[id]
+	if !ok || goal.UserID != userId {
+		return gorm.ErrRecordNotFound
+	}
+	delete(r.goals, id)
+	return nil
+}
+
+// preload returns a copy of goal with its category filled in.
+func (r *SavingsGoalRepository) preload(goal models.SavingsGoal) *models.SavingsGoal {
+	if goal.CategoryID != nil && r.Categories != nil {
+		if category, ok := r.Categories.get(*goal.CategoryID); ok {
+			goal.Category = &category
+		}
+	}
+	return &goal
+}
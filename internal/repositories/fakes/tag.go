@@ -0,0 +1,198 @@
+package fakes
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var _ repositories.TagRepository = (*TagRepository)(nil)
+
+// TagRepository stores tags in memory. Transactions is used by
+// SpendingByTag; it may be nil, in which case there is no spending.
+type TagRepository struct {
+	Transactions *TransactionRepository
+
+	mu   sync.Mutex
+	tags map[uuid.UUID]models.Tag
+}
+
+func NewTagRepository(transactions *TransactionRepository) *TagRepository {
+	return &TagRepository{Transactions: transactions, tags: map[uuid.UUID]models.Tag{}}
+}
+
+func (r *TagRepository) Create(ctx context.Context, tag *models.Tag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.insert(tag)
+}
+
+func (r *TagRepository) FindByID(ctx context.Context, id, userId uuid.UUID) (*models.Tag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tag, ok := r.tags[id]
+	if !ok || tag.UserID != userId {
+		return nil, nil
+	}
+	return &tag, nil
+}
+
+func (r *TagRepository) FindByName(ctx context.Context, userId uuid.UUID, name string) (*models.Tag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if tag, ok := r.byName(userId, name); ok {
+		return &tag, nil
+	}
+	return nil, nil
+}
+
+func (r *TagRepository) ListByUser(ctx context.Context, userId uuid.UUID) ([]*models.Tag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tags := []*models.Tag{}
+	for _, tag := range r.tags {
+		if tag.UserID == userId {
+			tag := tag
+			tags = append(tags, &tag)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+func (r *TagRepository) FindOrCreateByNames(ctx context.Context, userId uuid.UUID, names []string) ([]models.Tag, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tags := []models.Tag{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		tag, ok := r.byName(userId, name)
+		if !ok {
+			tag = models.Tag{UserID: userId, Name: name}
+			if err := r.insert(&tag); err != nil {
+				return nil, err
+			}
+		}
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+func (r *TagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.tags[tag.ID]
+	if !ok {
+		return nil
+	}
+	if existing, ok := r.byName(stored.UserID, tag.Name); ok && existing.ID != tag.ID {
+		return gorm.ErrDuplicatedKey
+	}
+	stored.Name = tag.Name
+	stored.Color = tag.Color
+	stored.UpdatedAt = time.Now()
+	r.tags[stored.ID] = stored
+	return nil
+}
+
+func (r *TagRepository) Delete(ctx context.Context, id, userId uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tag, ok := r.tags[id]
+	if !ok || tag.UserID != userId {
+		return gorm.ErrRecordNotFound
+	}
+	delete(r.tags, id)
+	return nil
+}
+
+func (r *TagRepository) SpendingByTag(ctx context.Context, userId uuid.UUID, from, to time.Time) ([]repositories.TagSpending, error) {
+	if r.Transactions == nil {
+		return []repositories.TagSpending{}, nil
+	}
+
+	totals := map[uuid.UUID]*repositories.TagSpending{}
+	for _, transaction := range r.Transactions.all(userId) {
+		if transaction.Date.Before(from) || transaction.Date.After(to) {
+			continue
+		}
+		for _, tag := range transaction.Tags {
+			if totals[tag.ID] == nil {
+				totals[tag.ID] = &repositories.TagSpending{TagID: tag.ID, Name: tag.Name}
+			}
+			switch transaction.Type {
+			case models.TransactionGroupIncome:
+				totals[tag.ID].Income += transaction.Amount
+			case models.TransactionGroupExpense:
+				totals[tag.ID].Expense += transaction.Amount
+			}
+			totals[tag.ID].Count++
+		}
+	}
+
+	spending := make([]repositories.TagSpending, 0, len(totals))
+	for _, total := range totals {
+		spending = append(spending, *total)
+	}
+	sort.Slice(spending, func(i, j int) bool {
+		if spending[i].Expense != spending[j].Expense {
+			return spending[i].Expense > spending[j].Expense
+		}
+		return spending[i].Name < spending[j].Name
+	})
+	return spending, nil
+}
+
+// insert stores a copy of tag, enforcing the unique (user_id, name) index;
+// callers hold r.mu.
+func (r *TagRepository) insert(tag *models.Tag) error {
+	if _, ok := r.byName(tag.UserID, tag.Name); ok {
+		return gorm.ErrDuplicatedKey
+	}
+	if tag.ID == uuid.Nil {
+		tag.ID = uuid.New()
+	}
+	if tag.Color == "" {
+		tag.Color = "#000000"
+	}
+	now := time.Now()
+	if tag.CreatedAt.IsZero() {
+		tag.CreatedAt = now
+	}
+	tag.UpdatedAt = now
+	r.tags[tag.ID] = *tag
+	return nil
+}
+
+// byName finds the user's tag called name; callers hold r.mu.
+func (r *TagRepository) byName(userId uuid.UUID, name string) (models.Tag, bool) {
+	for _, tag := range r.tags {
+		if tag.UserID == userId && tag.Name == name {
+			return tag, true
+		}
+	}
+	return models.Tag{}, false
+}
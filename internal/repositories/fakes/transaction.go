@@ -0,0 +1,290 @@
+package fakes
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var _ repositories.TransactionRepository = (*TransactionRepository)(nil)
+
+// TransactionRepository stores transactions in memory. Categories is used to
+// fill in preloaded categories and category names in totals; it may be nil,
+// in which case totals contain no categories, as the SQL join would.
+type TransactionRepository struct {
+	Categories *CategoryRepository
+
+	mu           sync.Mutex
+	transactions map[uuid.UUID]models.Transaction
+}
+
+func NewTransactionRepository(categories *CategoryRepository) *TransactionRepository {
+	return &TransactionRepository{Categories: categories, transactions: map[uuid.UUID]models.Transaction{}}
+}
+
+func (r *TransactionRepository) Create(ctx context.Context, transaction *models.Transaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.insert(transaction)
+	return nil
+}
+
+func (r *TransactionRepository) CreateBatch(ctx context.Context, transactions []*models.Transaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, transaction := range transactions {
+		r.insert(transaction)
+	}
+	return nil
+}
+
+func (r *TransactionRepository) Update(ctx context.Context, transaction *models.Transaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.transactions[transaction.ID]
+	if !ok || stored.UserID != transaction.UserID {
+		return nil
+	}
+	stored.CategoryID = transaction.CategoryID
+	stored.Type = transaction.Type
+	stored.Amount = transaction.Amount
+	stored.Description = transaction.Description
+	stored.Date = transaction.Date
+	stored.UpdatedAt = time.Now()
+	stored.Tags = append([]models.Tag(nil), transaction.Tags...)
+
+	for i := range transaction.Splits {
+		transaction.Splits[i].ID = uuid.New()
+		transaction.Splits[i].TransactionID = transaction.ID
+	}
+	stored.Splits = append([]models.TransactionSplit(nil), transaction.Splits...)
+
+	r.transactions[stored.ID] = stored
+	return nil
+}
+
+func (r *TransactionRepository) FindByID(ctx context.Context, id, userId uuid.UUID) (*models.Transaction, error) {
+	r.mu.Lock()
+	transaction, ok := r.transactions[id]
+	r.mu.Unlock()
+
+	if !ok || transaction.UserID != userId {
+		return nil, nil
+	}
+	return r.preload(transaction), nil
+}
+
+func (r *TransactionRepository) List(ctx context.Context, userId uuid.UUID, filter repositories.TransactionFilter) ([]*models.Transaction, int64, error) {
+	var transactions []*models.Transaction
+	for _, transaction := range r.all(userId) {
+		if filter.From != nil && transaction.Date.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && transaction.Date.After(*filter.To) {
+			continue
+		}
+		if filter.Type != nil && transaction.Type != *filter.Type {
+			continue
+		}
+		if filter.CategoryID != nil && !hasCategory(transaction, *filter.CategoryID) {
+			continue
+		}
+		if !hasTags(transaction, filter.Tags) {
+			continue
+		}
+		transactions = append(transactions, r.preload(transaction))
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		a, b := transactions[i], transactions[j]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.After(b.Date)
+		}
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+	return page(transactions, filter.Limit, filter.Offset), int64(len(transactions)), nil
+}
+
+func (r *TransactionRepository) Delete(ctx context.Context, id, userId uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	transaction, ok := r.transactions[id]
+	if !ok || transaction.UserID != userId {
+		return gorm.ErrRecordNotFound
+	}
+	delete(r.transactions, id)
+	return nil
+}
+
+func (r *TransactionRepository) SumByCategoryBetween(ctx context.Context, userId, categoryId uuid.UUID, txType models.TransactionGroupType, from, to time.Time) (float64, error) {
+	var total float64
+	for _, line := range r.lines(userId, from, to) {
+		if line.categoryID == categoryId && line.txType == txType {
+			total += line.amount
+		}
+	}
+	return total, nil
+}
+
+func (r *TransactionRepository) SumByCategory(ctx context.Context, userId uuid.UUID, from, to time.Time) ([]repositories.CategoryTotal, error) {
+	type key struct {
+		categoryID uuid.UUID
+		txType     models.TransactionGroupType
+	}
+	totals := map[key]*repositories.CategoryTotal{}
+	if r.Categories == nil {
+		return []repositories.CategoryTotal{}, nil
+	}
+	for _, line := range r.lines(userId, from, to) {
+		category, ok := r.Categories.get(line.categoryID)
+		if !ok {
+			continue
+		}
+		k := key{line.categoryID, line.txType}
+		if totals[k] == nil {
+			totals[k] = &repositories.CategoryTotal{CategoryID: category.ID, CategoryName: category.Name, Type: line.txType}
+		}
+		totals[k].Total += line.amount
+		totals[k].Count++
+	}
+
+	result := make([]repositories.CategoryTotal, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, *total)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Total > result[j].Total })
+	return result, nil
+}
+
+func (r *TransactionRepository) SumByType(ctx context.Context, userId uuid.UUID, from, to time.Time) (income, expense float64, err error) {
+	for _, transaction := range r.all(userId) {
+		if transaction.Date.Before(from) || transaction.Date.After(to) {
+			continue
+		}
+		switch transaction.Type {
+		case models.TransactionGroupIncome:
+			income += transaction.Amount
+		case models.TransactionGroupExpense:
+			expense += transaction.Amount
+		}
+	}
+	return income, expense, nil
+}
+
+// insert stores a copy of transaction; callers hold r.mu.
+func (r *TransactionRepository) insert(transaction *models.Transaction) {
+	if transaction.ID == uuid.Nil {
+		transaction.ID = uuid.New()
+	}
+	now := time.Now()
+	if transaction.CreatedAt.IsZero() {
+		transaction.CreatedAt = now
+	}
+	transaction.UpdatedAt = now
+	for i := range transaction.Splits {
+		if transaction.Splits[i].ID == uuid.Nil {
+			transaction.Splits[i].ID = uuid.New()
+		}
+		transaction.Splits[i].TransactionID = transaction.ID
+	}
+
+	stored := *transaction
+	stored.Splits = append([]models.TransactionSplit(nil), transaction.Splits...)
+	stored.Tags = append([]models.Tag(nil), transaction.Tags...)
+	r.transactions[stored.ID] = stored
+}
+
+// all returns copies of the user's transactions.
+func (r *TransactionRepository) all(userId uuid.UUID) []models.Transaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var transactions []models.Transaction
+	for _, transaction := range r.transactions {
+		if transaction.UserID == userId {
+			transactions = append(transactions, transaction)
+		}
+	}
+	return transactions
+}
+
+// preload returns a copy of transaction with its categories filled in.
+func (r *TransactionRepository) preload(transaction models.Transaction) *models.Transaction {
+	transaction.Splits = append([]models.TransactionSplit(nil), transaction.Splits...)
+	transaction.Tags = append([]models.Tag(nil), transaction.Tags...)
+	if r.Categories != nil {
+		if category, ok := r.Categories.get(transaction.CategoryID); ok {
+			transaction.Category = category
+		}
+		for i := range transaction.Splits {
+			if category, ok := r.Categories.get(transaction.Splits[i].CategoryID); ok {
+				transaction.Splits[i].Category = category
+			}
+		}
+	}
+	return &transaction
+}
+
+// transactionLine mirrors transactionLinesSQL: one line per split, or the
+// transaction itself when it has no splits.
+type transactionLine struct {
+	categoryID uuid.UUID
+	txType     models.TransactionGroupType
+	amount     float64
+}
+
+func (r *TransactionRepository) lines(userId uuid.UUID, from, to time.Time) []transactionLine {
+	var lines []transactionLine
+	for _, transaction := range r.all(userId) {
+		if transaction.Date.Before(from) || transaction.Date.After(to) {
+			continue
+		}
+		if len(transaction.Splits) == 0 {
+			lines = append(lines, transactionLine{transaction.CategoryID, transaction.Type, transaction.Amount})
+			continue
+		}
+		for _, split := range transaction.Splits {
+			lines = append(lines, transactionLine{split.CategoryID, transaction.Type, split.Amount})
+		}
+	}
+	return lines
+}
+
+func hasCategory(transaction models.Transaction, categoryId uuid.UUID) bool {
+	if transaction.CategoryID == categoryId {
+		return true
+	}
+	for _, split := range transaction.Splits {
+		if split.CategoryID == categoryId {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTags reports whether transaction carries every tag name in names.
+func hasTags(transaction models.Transaction, names []string) bool {
+	for _, name := range names {
+		found := false
+		for _, tag := range transaction.Tags {
+			if tag.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,16 @@
+package fakes
+
+import (
+	"context"
+	"gin-backend-app/internal/repositories"
+)
+
+var _ repositories.TxManager = TxManager{}
+
+// TxManager runs fn directly. The in-memory repositories have no rollback,
+// so writes made before fn fails are kept.
+type TxManager struct{}
+
+func (TxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,193 @@
+// Package fakes provides in-memory implementations of the repository
+// interfaces for unit tests. They keep copies of the stored models, so
+// mutating a returned value does not change the store, and they report "not
+// found" the same way as the gorm repositories.
+package fakes
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var _ repositories.UserRepository = (*UserRepository)(nil)
+
+type UserRepository struct {
+	mu    sync.Mutex
+	users map[uuid.UUID]models.User
+}
+
+func NewUserRepository(users ...*models.User) *UserRepository {
+	r := &UserRepository{users: map[uuid.UUID]models.User{}}
+	for _, user := range users {
+		if err := r.Create(context.Background(), user); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.users {
+		if strings.EqualFold(existing.Email, user.Email) {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
+	}
+	if user.Locale == "" {
+		user.Locale = "en"
+	}
+	now := time.Now()
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = now
+	}
+	user.UpdatedAt = now
+	r.users[user.ID] = *user
+	return nil
+}
+
+func (r *UserRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, nil
+	}
+	return &user, nil
+}
+
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	return r.find(func(user models.User) bool { return user.Email == email }), nil
+}
+
+func (r *UserRepository) FindByEmailOrUsername(ctx context.Context, email, username string) (*models.User, error) {
+	return r.find(func(user models.User) bool { return user.Email == email || user.Name == username }), nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user.UpdatedAt = time.Now()
+	r.users[user.ID] = *user
+	return nil
+}
+
+func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(r.users, id)
+	return nil
+}
+
+func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]*models.User, error) {
+	return page(r.sorted(nil), limit, offset), nil
+}
+
+func (r *UserRepository) Count(ctx context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return int64(len(r.users)), nil
+}
+
+func (r *UserRepository) MarkEmailVerified(ctx context.Context, userId uuid.UUID) error {
+	now := time.Now()
+	return r.update(userId, func(user *models.User) {
+		user.IsEmailVerified = true
+		user.EmailVerifiedAt = &now
+	})
+}
+
+func (r *UserRepository) ChangePassword(ctx context.Context, hashedPassword string, userId uuid.UUID) error {
+	return r.update(userId, func(user *models.User) { user.Password = hashedPassword })
+}
+
+func (r *UserRepository) ListDigestSubscribers(ctx context.Context, periodType models.PeriodType, afterID uuid.UUID, limit int) ([]*models.User, error) {
+	users := r.sorted(func(user models.User) bool {
+		subscribed := user.DigestMonthly
+		if periodType == models.PeriodWeekly {
+			subscribed = user.DigestWeekly
+		}
+		return subscribed && user.IsEmailVerified && user.ID.String() > afterID.String()
+	})
+	return page(users, limit, 0), nil
+}
+
+func (r *UserRepository) UpdatePreferences(ctx context.Context, userId uuid.UUID, locale string, digestWeekly, digestMonthly bool) error {
+	return r.update(userId, func(user *models.User) {
+		user.Locale = locale
+		user.DigestWeekly = digestWeekly
+		user.DigestMonthly = digestMonthly
+		user.UpdatedAt = time.Now()
+	})
+}
+
+func (r *UserRepository) find(match func(models.User) bool) *models.User {
+	users := r.sorted(match)
+	if len(users) == 0 {
+		return nil
+	}
+	return users[0]
+}
+
+// sorted returns copies of the users matching keep (all when nil), ordered by
+// id like the keyset queries of the gorm repository.
+func (r *UserRepository) sorted(keep func(models.User) bool) []*models.User {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	users := make([]*models.User, 0, len(r.users))
+	for _, user := range r.users {
+		if keep == nil || keep(user) {
+			user := user
+			users = append(users, &user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID.String() < users[j].ID.String() })
+	return users
+}
+
+// update applies fn to the stored user; like the gorm repository, updating a
+// missing user is not an error.
+func (r *UserRepository) update(id uuid.UUID, fn func(*models.User)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil
+	}
+	fn(&user)
+	r.users[id] = user
+	return nil
+}
+
+// page applies a LIMIT/OFFSET to items; a limit of 0 or less means no limit.
+func page[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
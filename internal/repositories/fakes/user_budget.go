@@ -0,0 +1,73 @@
+package fakes
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var _ repositories.UserBudgetRepository = (*UserBudgetRepository)(nil)
+
+// UserBudgetRepository stores budgets in memory. Categories is used to fill
+// in preloaded categories; it may be nil.
+type UserBudgetRepository struct {
+	Categories *CategoryRepository
+
+	mu      sync.Mutex
+	budgets []models.UserBudget
+}
+
+func NewUserBudgetRepository(categories *CategoryRepository, budgets ...*models.UserBudget) *UserBudgetRepository {
+	r := &UserBudgetRepository{Categories: categories}
+	for _, budget := range budgets {
+		r.Add(budget)
+	}
+	return r
+}
+
+// Add stores budget, assigning an id when it has none. No service creates
+// budgets, so the interface has no Create.
+func (r *UserBudgetRepository) Add(budget *models.UserBudget) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if budget.ID == uuid.Nil {
+		budget.ID = uuid.New()
+	}
+	now := time.Now()
+	if budget.CreatedAt.IsZero() {
+		budget.CreatedAt = now
+	}
+	budget.UpdatedAt = now
+	r.budgets = append(r.budgets, *budget)
+}
+
+func (r *UserBudgetRepository) ListActiveByUser(ctx context.Context, userId uuid.UUID, at time.Time) ([]*models.UserBudget, error) {
+	r.mu.Lock()
+	var active []models.UserBudget
+	for _, budget := range r.budgets {
+		if budget.UserID != userId || !budget.IsActive || budget.StartDate.After(at) {
+			continue
+		}
+		if budget.EndDate != nil && budget.EndDate.Before(at) {
+			continue
+		}
+		active = append(active, budget)
+	}
+	r.mu.Unlock()
+
+	budgets := make([]*models.UserBudget, 0, len(active))
+	for _, budget := range active {
+		if r.Categories != nil {
+			if category, ok := r.Categories.get(budget.CategoryID); ok {
+				budget.Category = category
+			}
+		}
+		budgets = append(budgets, &budget)
+	}
+	return budgets, nil
+}
@@ -0,0 +1,181 @@
+package fakes
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"gin-backend-app/pkg/utils"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var _ repositories.UserTokenRepository = (*UserTokenRepository)(nil)
+
+// UserTokenRepository stores tokens in memory. Users is used to fill in
+// token owners the way the gorm repository's joins do; it may be nil.
+type UserTokenRepository struct {
+	Users *UserRepository
+
+	mu     sync.Mutex
+	tokens []models.UserToken
+}
+
+func NewUserTokenRepository(users *UserRepository) *UserTokenRepository {
+	return &UserTokenRepository{Users: users}
+}
+
+// Tokens returns copies of all stored tokens in creation order.
+func (r *UserTokenRepository) Tokens() []models.UserToken {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]models.UserToken(nil), r.tokens...)
+}
+
+func (r *UserTokenRepository) Create(ctx context.Context, token *models.UserToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.tokens {
+		if existing.TokenOtp == token.TokenOtp {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+	if token.CreatedAt.IsZero() {
+		token.CreatedAt = time.Now()
+	}
+	r.tokens = append(r.tokens, *token)
+	return nil
+}
+
+func (r *UserTokenRepository) FindTokenByOTP(ctx context.Context, otp string, tokenType models.TokenType, userId uuid.UUID) (*models.UserToken, error) {
+	return r.first(func(token *models.UserToken) bool {
+		return token.TokenOtp == otp && token.TokenType == tokenType && token.UserID == userId
+	})
+}
+
+func (r *UserTokenRepository) FindTokenByUserId(ctx context.Context, userId uuid.UUID) (*models.UserToken, error) {
+	return r.first(func(token *models.UserToken) bool { return token.UserID == userId })
+}
+
+func (r *UserTokenRepository) MarkAsUsedToken(ctx context.Context, tokenID uuid.UUID) error {
+	now := time.Now()
+	r.each(func(token *models.UserToken) {
+		if token.ID == tokenID {
+			token.UsedAt = &now
+		}
+	})
+	return nil
+}
+
+func (r *UserTokenRepository) CheckUserTokenCreatedAt(ctx context.Context, userId uuid.UUID) bool {
+	oneMinuteAgo := time.Now().Add(-1 * time.Minute)
+	token, _ := r.first(func(token *models.UserToken) bool {
+		return token.UserID == userId && token.TokenType == models.TokenTypeEmailVerification && !token.CreatedAt.Before(oneMinuteAgo)
+	})
+	return token == nil
+}
+
+func (r *UserTokenRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.tokens[:0]
+	for _, token := range r.tokens {
+		if token.ExpiresAt.Before(now) && token.UsedAt == nil {
+			continue
+		}
+		kept = append(kept, token)
+	}
+	deleted := int64(len(r.tokens) - len(kept))
+	r.tokens = kept
+	return deleted, nil
+}
+
+func (r *UserTokenRepository) GenerateAndSetVerificationTokenByOTP(ctx context.Context, otp, email string, tokenType models.TokenType, userId uuid.UUID) (string, error) {
+	verifyToken, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	updated := false
+	r.each(func(token *models.UserToken) {
+		if token.UserID == userId && token.TokenOtp == otp && token.TokenType == tokenType &&
+			token.ExpiresAt.After(now) && token.VerifyToken == nil {
+			token.VerifyToken = &verifyToken
+			updated = true
+		}
+	})
+	if !updated {
+		return "", repositories.ErrInvalidOTP
+	}
+	return verifyToken, nil
+}
+
+func (r *UserTokenRepository) ValidateTokenAndGetUser(ctx context.Context, verificationToken string) (*models.User, error) {
+	now := time.Now()
+	token, _ := r.first(func(token *models.UserToken) bool {
+		return token.VerifyToken != nil && *token.VerifyToken == verificationToken &&
+			token.ExpiresAt.After(now) && token.UsedAt == nil
+	})
+	if token == nil {
+		return nil, repositories.ErrInvalidVerifyToken
+	}
+	return &token.User, nil
+}
+
+func (r *UserTokenRepository) MarkVerifyTokenUsed(ctx context.Context, verificationToken string) error {
+	now := time.Now()
+	updated := false
+	r.each(func(token *models.UserToken) {
+		if token.VerifyToken != nil && *token.VerifyToken == verificationToken && token.UsedAt == nil {
+			token.UsedAt = &now
+			updated = true
+		}
+	})
+	if !updated {
+		return repositories.ErrInvalidVerifyToken
+	}
+	return nil
+}
+
+// first returns a copy of the newest token matching match, or
+// gorm.ErrRecordNotFound like the gorm repository's First.
+func (r *UserTokenRepository) first(match func(*models.UserToken) bool) (*models.UserToken, error) {
+	var found []models.UserToken
+	r.each(func(token *models.UserToken) {
+		if match(token) {
+			found = append(found, *token)
+		}
+	})
+	if len(found) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].CreatedAt.After(found[j].CreatedAt) })
+
+	token := found[0]
+	if r.Users != nil {
+		if user, _ := r.Users.FindByID(context.Background(), token.UserID); user != nil {
+			token.User = *user
+		}
+	}
+	return &token, nil
+}
+
+// each calls fn with a pointer to every stored token.
+func (r *UserTokenRepository) each(fn func(*models.UserToken)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.tokens {
+		fn(&r.tokens[i])
+	}
+}
@@ -0,0 +1,9 @@
+package repositories
+
+import (
+	"testing"
+
+	"gin-backend-app/internal/testutil/pgtest"
+)
+
+func TestMain(m *testing.M) { pgtest.Main(m) }
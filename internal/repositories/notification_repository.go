@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/realtime"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *models.Notification) error
+	List(ctx context.Context, userId uuid.UUID, unreadOnly bool, limit, offset int) ([]*models.Notification, int64, error)
+	CountUnread(ctx context.Context, userId uuid.UUID) (int64, error)
+	MarkRead(ctx context.Context, id, userId uuid.UUID, at time.Time) error
+	MarkAllRead(ctx context.Context, userId uuid.UUID, at time.Time) (int64, error)
+	Publish(ctx context.Context, userId uuid.UUID, eventType string, data any) error
+}
+
+type notificationRepository struct {
+	DB *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{DB: db}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	return conn(ctx, r.DB).Model(&models.Notification{}).Create(notification).Error
+}
+
+// List returns the user's notifications, newest first, and the total count
+// matching the filter.
+func (r *notificationRepository) List(ctx context.Context, userId uuid.UUID, unreadOnly bool, limit, offset int) ([]*models.Notification, int64, error) {
+	query := conn(ctx, r.DB).Model(&models.Notification{}).Where("user_id = ?", userId)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notifications []*models.Notification
+	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&notifications).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, userId uuid.UUID) (int64, error) {
+	var count int64
+	err := conn(ctx, r.DB).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userId).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead sets read_at on one notification, keeping the original time if
+// it was already read.
+func (r *notificationRepository) MarkRead(ctx context.Context, id, userId uuid.UUID, at time.Time) error {
+	tx := conn(ctx, r.DB).Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", id, userId).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", at))
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, userId uuid.UUID, at time.Time) (int64, error) {
+	tx := conn(ctx, r.DB).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userId).
+		Update("read_at", at)
+	return tx.RowsAffected, tx.Error
+}
+
+// Publish sends a realtime event to the user's live streams. Inside a
+// transaction it is delivered only on commit.
+func (r *notificationRepository) Publish(ctx context.Context, userId uuid.UUID, eventType string, data any) error {
+	return realtime.NotifyTx(conn(ctx, r.DB), userId, eventType, data)
+}
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"gin-backend-app/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PeriodReportRepository interface {
+	FindByPeriod(ctx context.Context, userId uuid.UUID, periodType models.PeriodType, periodStart time.Time) (*models.PeriodReport, error)
+	Save(ctx context.Context, report *models.PeriodReport) error
+}
+
+type periodReportRepository struct {
+	DB *gorm.DB
+}
+
+func NewPeriodReportRepository(db *gorm.DB) PeriodReportRepository {
+	return &periodReportRepository{DB: db}
+}
+
+func (r *periodReportRepository) FindByPeriod(ctx context.Context, userId uuid.UUID, periodType models.PeriodType, periodStart time.Time) (*models.PeriodReport, error) {
+	var report models.PeriodReport
+	err := conn(ctx, r.DB).Model(&models.PeriodReport{}).
+		Where("user_id = ? AND period_type = ? AND period_start = ?", userId, periodType, periodStart).
+		First(&report).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &report, nil
+}
+
+// Save inserts the report or refreshes the existing one for the same period.
+func (r *periodReportRepository) Save(ctx context.Context, report *models.PeriodReport) error {
+	existing, err := r.FindByPeriod(ctx, report.UserID, report.PeriodType, report.PeriodStart)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		return conn(ctx, r.DB).Create(report).Error
+	}
+
+	report.ID = existing.ID
+	report.CreatedAt = existing.CreatedAt
+	return conn(ctx, r.DB).Model(report).Select(
+		"period_value", "period_end", "total_income", "total_expense", "net_flow", "report_data", "generated_at", "updated_at",
+	).Updates(report).Error
+}
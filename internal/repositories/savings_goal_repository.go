@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"gin-backend-app/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SavingsGoalRepository interface {
+	Create(ctx context.Context, goal *models.SavingsGoal) error
+	FindByID(ctx context.Context, id, userId uuid.UUID) (*models.SavingsGoal, error)
+	ListByUser(ctx context.Context, userId uuid.UUID) ([]*models.SavingsGoal, error)
+	Update(ctx context.Context, goal *models.SavingsGoal) error
+	Delete(ctx context.Context, id, userId uuid.UUID) error
+}
+
+type savingsGoalRepository struct {
+	DB *gorm.DB
+}
+
+func NewSavingsGoalRepository(db *gorm.DB) SavingsGoalRepository {
+	return &savingsGoalRepository{DB: db}
+}
+
+func (r *savingsGoalRepository) Create(ctx context.Context, goal *models.SavingsGoal) error {
+	return conn(ctx, r.DB).Model(&models.SavingsGoal{}).Create(goal).Error
+}
+
+func (r *savingsGoalRepository) FindByID(ctx context.Context, id, userId uuid.UUID) (*models.SavingsGoal, error) {
+	var goal models.SavingsGoal
+	err := conn(ctx, r.DB).Model(&models.SavingsGoal{}).
+		Preload("Category").
+		Where("id = ? AND user_id = ?", id, userId).
+		First(&goal).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &goal, nil
+}
+
+func (r *savingsGoalRepository) ListByUser(ctx context.Context, userId uuid.UUID) ([]*models.SavingsGoal, error) {
+	var goals []*models.SavingsGoal
+	err := conn(ctx, r.DB).Model(&models.SavingsGoal{}).
+		Preload("Category").
+		Where("user_id = ?", userId).
+		Order("deadline ASC").
+		Find(&goals).Error
+	if err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+func (r *savingsGoalRepository) Update(ctx context.Context, goal *models.SavingsGoal) error {
+	return conn(ctx, r.DB).Model(goal).Select(
+		"name", "target_amount", "category_id", "start_date", "deadline", "description", "updated_at",
+	).Updates(goal).Error
+}
+
+func (r *savingsGoalRepository) Delete(ctx context.Context, id, userId uuid.UUID) error {
+	tx := conn(ctx, r.DB).Delete(&models.SavingsGoal{}, "id = ? AND user_id = ?", id, userId)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"gin-backend-app/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagSpending struct {
+	TagID   uuid.UUID `json:"tag_id"`
+	Name    string    `json:"name"`
+	Income  float64   `json:"income"`
+	Expense float64   `json:"expense"`
+	Count   int64     `json:"count"`
+}
+
+type TagRepository interface {
+	Create(ctx context.Context, tag *models.Tag) error
+	FindByID(ctx context.Context, id, userId uuid.UUID) (*models.Tag, error)
+	FindByName(ctx context.Context, userId uuid.UUID, name string) (*models.Tag, error)
+	ListByUser(ctx context.Context, userId uuid.UUID) ([]*models.Tag, error)
+	FindOrCreateByNames(ctx context.Context, userId uuid.UUID, names []string) ([]models.Tag, error)
+	Update(ctx context.Context, tag *models.Tag) error
+	Delete(ctx context.Context, id, userId uuid.UUID) error
+	SpendingByTag(ctx context.Context, userId uuid.UUID, from, to time.Time) ([]TagSpending, error)
+}
+
+type tagRepository struct {
+	DB *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{DB: db}
+}
+
+func (r *tagRepository) Create(ctx context.Context, tag *models.Tag) error {
+	return conn(ctx, r.DB).Model(&models.Tag{}).Create(tag).Error
+}
+
+func (r *tagRepository) FindByID(ctx context.Context, id, userId uuid.UUID) (*models.Tag, error) {
+	var tag models.Tag
+	err := conn(ctx, r.DB).Model(&models.Tag{}).Where("id = ? AND user_id = ?", id, userId).First(&tag).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (r *tagRepository) FindByName(ctx context.Context, userId uuid.UUID, name string) (*models.Tag, error) {
+	var tag models.Tag
+	err := conn(ctx, r.DB).Model(&models.Tag{}).Where("user_id = ? AND name = ?", userId, name).First(&tag).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (r *tagRepository) ListByUser(ctx context.Context, userId uuid.UUID) ([]*models.Tag, error) {
+	var tags []*models.Tag
+	err := conn(ctx, r.DB).Model(&models.Tag{}).Where("user_id = ?", userId).Order("name ASC").Find(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// FindOrCreateByNames returns the user's tags with the given (normalized)
+// names, creating the missing ones.
+func (r *tagRepository) FindOrCreateByNames(ctx context.Context, userId uuid.UUID, names []string) ([]models.Tag, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	tags := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, models.Tag{UserID: userId, Name: name})
+	}
+
+	err := conn(ctx, r.DB).Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []models.Tag
+	err = conn(ctx, r.DB).Model(&models.Tag{}).Where("user_id = ? AND name IN ?", userId, names).Order("name ASC").Find(&existing).Error
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (r *tagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	return conn(ctx, r.DB).Model(tag).Select("name", "color", "updated_at").Updates(tag).Error
+}
+
+func (r *tagRepository) Delete(ctx context.Context, id, userId uuid.UUID) error {
+	tx := conn(ctx, r.DB).Delete(&models.Tag{}, "id = ? AND user_id = ?", id, userId)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// SpendingByTag sums transaction amounts per tag between from and to. The
+// query starts from the user's transactions in the date range so it is
+// driven by idx_transactions_user_date and joins transaction_tags by its
+// primary key.
+func (r *tagRepository) SpendingByTag(ctx context.Context, userId uuid.UUID, from, to time.Time) ([]TagSpending, error) {
+	var spending []TagSpending
+	err := conn(ctx, r.DB).Table("transactions t").
+		Select(`tg.id AS tag_id, tg.name,
+			COALESCE(SUM(t.amount) FILTER (WHERE t.type = ?), 0) AS income,
+			COALESCE(SUM(t.amount) FILTER (WHERE t.type = ?), 0) AS expense,
+			COUNT(*) AS count`, models.TransactionGroupIncome, models.TransactionGroupExpense).
+		Joins("JOIN transaction_tags tt ON tt.transaction_id = t.id").
+		Joins("JOIN tags tg ON tg.id = tt.tag_id").
+		Where("t.user_id = ?", userId).
+		Where("t.date BETWEEN ? AND ?", from, to).
+		Group("tg.id, tg.name").
+		Order("expense DESC, tg.name ASC").
+		Scan(&spending).Error
+	if err != nil {
+		return nil, err
+	}
+	return spending, nil
+}
@@ -0,0 +1,249 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"gin-backend-app/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// transactionLinesSQL expands every transaction into the lines that should be
+// aggregated per category: one line per split when the transaction has
+// splits, otherwise the transaction itself.
+const transactionLinesSQL = `
+	SELECT t.id AS transaction_id, t.user_id, t.type, t.date,
+		COALESCE(s.category_id, t.category_id) AS category_id,
+		COALESCE(s.amount, t.amount) AS amount
+	FROM transactions t
+	LEFT JOIN transaction_splits s ON s.transaction_id = t.id`
+
+type TransactionFilter struct {
+	From       *time.Time
+	To         *time.Time
+	CategoryID *uuid.UUID
+	Type       *models.TransactionGroupType
+	// Tags restricts the result to transactions carrying all of the given
+	// (normalized) tag names.
+	Tags   []string
+	Limit  int
+	Offset int
+}
+
+type CategoryTotal struct {
+	CategoryID   uuid.UUID                   `json:"category_id"`
+	CategoryName string                      `json:"category_name"`
+	Type         models.TransactionGroupType `json:"type"`
+	Total        float64                     `json:"total"`
+	Count        int64                       `json:"count"`
+}
+
+type TransactionRepository interface {
+	Create(ctx context.Context, transaction *models.Transaction) error
+	CreateBatch(ctx context.Context, transactions []*models.Transaction) error
+	Update(ctx context.Context, transaction *models.Transaction) error
+	FindByID(ctx context.Context, id, userId uuid.UUID) (*models.Transaction, error)
+	List(ctx context.Context, userId uuid.UUID, filter TransactionFilter) ([]*models.Transaction, int64, error)
+	Delete(ctx context.Context, id, userId uuid.UUID) error
+	SumByCategoryBetween(ctx context.Context, userId, categoryId uuid.UUID, txType models.TransactionGroupType, from, to time.Time) (float64, error)
+	SumByCategory(ctx context.Context, userId uuid.UUID, from, to time.Time) ([]CategoryTotal, error)
+	SumByType(ctx context.Context, userId uuid.UUID, from, to time.Time) (income, expense float64, err error)
+}
+
+type transactionRepository struct {
+	DB *gorm.DB
+}
+
+func NewTransactionRepository(db *gorm.DB) TransactionRepository {
+	return &transactionRepository{DB: db}
+}
+
+// Create inserts the transaction together with its splits.
+func (r *transactionRepository) Create(ctx context.Context, transaction *models.Transaction) error {
+	return conn(ctx, r.DB).Create(transaction).Error
+}
+
+// CreateBatch inserts all transactions (and their splits) atomically.
+func (r *transactionRepository) CreateBatch(ctx context.Context, transactions []*models.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+	return conn(ctx, r.DB).Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(transactions, 100).Error
+	})
+}
+
+// Update saves the transaction columns and replaces its splits.
+func (r *transactionRepository) Update(ctx context.Context, transaction *models.Transaction) error {
+	return conn(ctx, r.DB).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Transaction{}).Where("id = ? AND user_id = ?", transaction.ID, transaction.UserID).Updates(map[string]interface{}{
+			"category_id": transaction.CategoryID,
+			"type":        transaction.Type,
+			"amount":      transaction.Amount,
+			"description": transaction.Description,
+			"date":        transaction.Date,
+			"updated_at":  time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("transaction_id = ?", transaction.ID).Delete(&models.TransactionSplit{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(transaction).Association("Tags").Replace(transaction.Tags); err != nil {
+			return err
+		}
+
+		if len(transaction.Splits) == 0 {
+			return nil
+		}
+		for i := range transaction.Splits {
+			transaction.Splits[i].ID = uuid.Nil
+			transaction.Splits[i].TransactionID = transaction.ID
+		}
+		return tx.Create(&transaction.Splits).Error
+	})
+}
+
+func (r *transactionRepository) FindByID(ctx context.Context, id, userId uuid.UUID) (*models.Transaction, error) {
+	var transaction models.Transaction
+	err := conn(ctx, r.DB).Model(&models.Transaction{}).
+		Preload("Category").
+		Preload("Splits.Category").
+		Preload("Tags").
+		Where("id = ? AND user_id = ?", id, userId).
+		First(&transaction).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &transaction, nil
+}
+
+func (r *transactionRepository) List(ctx context.Context, userId uuid.UUID, filter TransactionFilter) ([]*models.Transaction, int64, error) {
+	query := conn(ctx, r.DB).Model(&models.Transaction{}).Where("user_id = ?", userId)
+	if filter.From != nil {
+		query = query.Where("date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("date <= ?", *filter.To)
+	}
+	if filter.Type != nil {
+		query = query.Where("type = ?", *filter.Type)
+	}
+	if filter.CategoryID != nil {
+		query = query.Where(
+			"(category_id = ? OR EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = transactions.id AND s.category_id = ?))",
+			*filter.CategoryID, *filter.CategoryID,
+		)
+	}
+	// EXISTS keeps the planner on idx_transactions_user_date and probes
+	// transaction_tags by its (transaction_id, tag_id) primary key per row.
+	for _, tag := range filter.Tags {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM transaction_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.transaction_id = transactions.id AND tg.name = ?)",
+			tag,
+		)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var transactions []*models.Transaction
+	err := query.
+		Preload("Category").
+		Preload("Splits.Category").
+		Preload("Tags").
+		Order("date DESC, created_at DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&transactions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return transactions, total, nil
+}
+
+func (r *transactionRepository) Delete(ctx context.Context, id, userId uuid.UUID) error {
+	tx := conn(ctx, r.DB).Delete(&models.Transaction{}, "id = ? AND user_id = ?", id, userId)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// SumByCategoryBetween returns the total amount of txType lines booked to a
+// category between from and to (both inclusive, compared by date), counting
+// split lines.
+func (r *transactionRepository) SumByCategoryBetween(ctx context.Context, userId, categoryId uuid.UUID, txType models.TransactionGroupType, from, to time.Time) (float64, error) {
+	var total float64
+	err := conn(ctx, r.DB).Table("("+transactionLinesSQL+") AS lines").
+		Select("COALESCE(SUM(amount), 0)").
+		Where("user_id = ? AND category_id = ? AND type = ?", userId, categoryId, txType).
+		Where("date BETWEEN ? AND ?", from, to).
+		Scan(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// SumByCategory aggregates split-aware lines per category between from and to.
+func (r *transactionRepository) SumByCategory(ctx context.Context, userId uuid.UUID, from, to time.Time) ([]CategoryTotal, error) {
+	var totals []CategoryTotal
+	err := conn(ctx, r.DB).Table("("+transactionLinesSQL+") AS lines").
+		Select("lines.category_id, c.name AS category_name, lines.type, SUM(lines.amount) AS total, COUNT(*) AS count").
+		Joins("JOIN categories c ON c.id = lines.category_id").
+		Where("lines.user_id = ?", userId).
+		Where("lines.date BETWEEN ? AND ?", from, to).
+		Group("lines.category_id, c.name, lines.type").
+		Order("total DESC").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// SumByType returns income and expense totals between from and to.
+func (r *transactionRepository) SumByType(ctx context.Context, userId uuid.UUID, from, to time.Time) (income, expense float64, err error) {
+	var rows []struct {
+		Type  models.TransactionGroupType
+		Total float64
+	}
+	err = conn(ctx, r.DB).Model(&models.Transaction{}).
+		Select("type, COALESCE(SUM(amount), 0) AS total").
+		Where("user_id = ?", userId).
+		Where("date BETWEEN ? AND ?", from, to).
+		Group("type").
+		Scan(&rows).Error
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, row := range rows {
+		switch row.Type {
+		case models.TransactionGroupIncome:
+			income = row.Total
+		case models.TransactionGroupExpense:
+			expense = row.Total
+		}
+	}
+	return income, expense, nil
+}
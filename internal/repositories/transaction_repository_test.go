@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/testutil/fixtures"
+	"gin-backend-app/internal/testutil/pgtest"
+)
+
+func TestSumByCategoryBetweenFiltersByType(t *testing.T) {
+	t.Parallel()
+	db := pgtest.New(t)
+	repo := NewTransactionRepository(db)
+
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	from, to := day.AddDate(0, 0, -9), day.AddDate(0, 0, 21)
+
+	user := fixtures.NewUser().Create(t, db)
+	savings := fixtures.NewCategory(user.ID).Named("Savings").Create(t, db)
+	food := fixtures.NewCategory(user.ID).Named("Food").Create(t, db)
+
+	fixtures.NewTransaction(user.ID, savings.ID).Amount(30000).On(day).Create(t, db)
+	fixtures.NewTransaction(user.ID, savings.ID).Amount(5000).Income().On(day).Create(t, db)
+	fixtures.NewTransaction(user.ID, food.ID).Amount(12000).On(day).
+		Split(food.ID, 8000).Split(savings.ID, 4000).Create(t, db)
+	// Outside the range.
+	fixtures.NewTransaction(user.ID, savings.ID).Amount(99000).On(day.AddDate(0, -2, 0)).Create(t, db)
+	// Another user's spending in the same period.
+	other := fixtures.NewUser().Create(t, db)
+	otherSavings := fixtures.NewCategory(other.ID).Create(t, db)
+	fixtures.NewTransaction(other.ID, otherSavings.ID).Amount(70000).On(day).Create(t, db)
+
+	tests := []struct {
+		txType models.TransactionGroupType
+		want   float64
+	}{
+		{models.TransactionGroupExpense, 34000},
+		{models.TransactionGroupIncome, 5000},
+	}
+	for _, tt := range tests {
+		got, err := repo.SumByCategoryBetween(context.Background(), user.ID, savings.ID, tt.txType, from, to)
+		if err != nil {
+			t.Fatalf("SumByCategoryBetween(%s): %v", tt.txType, err)
+		}
+		if got != tt.want {
+			t.Errorf("SumByCategoryBetween(%s) = %v, want %v", tt.txType, got, tt.want)
+		}
+	}
+}
+
+func TestDeleteTransactionOfAnotherUser(t *testing.T) {
+	t.Parallel()
+	db := pgtest.New(t)
+	repo := NewTransactionRepository(db)
+
+	user := fixtures.NewUser().Create(t, db)
+	category := fixtures.NewCategory(user.ID).Create(t, db)
+	transaction := fixtures.NewTransaction(user.ID, category.ID).Create(t, db)
+	other := fixtures.NewUser().Create(t, db)
+
+	if err := repo.Delete(context.Background(), transaction.ID, other.ID); err == nil {
+		t.Fatal("deleting another user's transaction succeeded")
+	}
+	if found, err := repo.FindByID(context.Background(), transaction.ID, user.ID); err != nil || found == nil {
+		t.Fatalf("FindByID after failed delete = %v, %v; want the transaction", found, err)
+	}
+
+	if err := repo.Delete(context.Background(), transaction.ID, user.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if found, err := repo.FindByID(context.Background(), transaction.ID, user.ID); err != nil || found != nil {
+		t.Fatalf("FindByID after delete = %v, %v; want nil", found, err)
+	}
+}
@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// TxManager runs multi-repository operations atomically. The transaction
+// travels in the ctx passed to fn: every repository method called with that
+// ctx joins it, so services compose without handing *gorm.DB around.
+type TxManager interface {
+	// WithinTransaction commits when fn returns nil and rolls back
+	// otherwise. When ctx already carries a transaction, fn runs in a
+	// savepoint of it, so a failing inner step can be rolled back on its own
+	// while the outer transaction decides the final outcome.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type gormTxManager struct {
+	DB *gorm.DB
+}
+
+func NewTxManager(db *gorm.DB) TxManager {
+	return &gormTxManager{DB: db}
+}
+
+func (m *gormTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Transaction on a *gorm.DB that is already a transaction issues
+	// SAVEPOINT/ROLLBACK TO instead of BEGIN/COMMIT.
+	return conn(ctx, m.DB).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn returns the transaction carried by ctx, or db bound to ctx so the
+// query is cancelled with it.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return db.WithContext(ctx)
+}
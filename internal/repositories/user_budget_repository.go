@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+	"gin-backend-app/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type UserBudgetRepository interface {
+	ListActiveByUser(ctx context.Context, userId uuid.UUID, at time.Time) ([]*models.UserBudget, error)
+}
+
+type userBudgetRepository struct {
+	DB *gorm.DB
+}
+
+func NewUserBudgetRepository(db *gorm.DB) UserBudgetRepository {
+	return &userBudgetRepository{DB: db}
+}
+
+// ListActiveByUser returns budgets that are active and cover the given date.
+func (r *userBudgetRepository) ListActiveByUser(ctx context.Context, userId uuid.UUID, at time.Time) ([]*models.UserBudget, error) {
+	var budgets []*models.UserBudget
+	err := conn(ctx, r.DB).Model(&models.UserBudget{}).
+		Preload("Category").
+		Where("user_id = ? AND is_active = ?", userId, true).
+		Where("start_date <= ?", at).
+		Where("end_date IS NULL OR end_date >= ?", at).
+		Find(&budgets).Error
+	if err != nil {
+		return nil, err
+	}
+	return budgets, nil
+}
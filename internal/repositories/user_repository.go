@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"gin-backend-app/internal/models"
 	"time"
@@ -9,25 +10,40 @@ import (
 	"gorm.io/gorm"
 )
 
-type UserRepository struct {
+type UserRepository interface {
+	Create(ctx context.Context, user *models.User) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	FindByEmailOrUsername(ctx context.Context, email, username string) (*models.User, error)
+	Update(ctx context.Context, user *models.User) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	List(ctx context.Context, limit, offset int) ([]*models.User, error)
+	Count(ctx context.Context) (int64, error)
+	MarkEmailVerified(ctx context.Context, userId uuid.UUID) error
+	ChangePassword(ctx context.Context, hashedPassword string, userId uuid.UUID) error
+	ListDigestSubscribers(ctx context.Context, periodType models.PeriodType, afterID uuid.UUID, limit int) ([]*models.User, error)
+	UpdatePreferences(ctx context.Context, userId uuid.UUID, locale string, digestWeekly, digestMonthly bool) error
+}
+
+type userRepository struct {
     DB *gorm.DB
 }
 
-func NewUserRepository(db *gorm.DB) *UserRepository {
-    return &UserRepository{DB: db}
+func NewUserRepository(db *gorm.DB) UserRepository {
+    return &userRepository{DB: db}
 }
 
-func (r *UserRepository) Create(user *models.User) error {
-    err := r.DB.Model(&models.User{}).Create(user).Error
+func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+    err := conn(ctx, r.DB).Model(&models.User{}).Create(user).Error
     if err != nil {
         return err
     }
     return nil
 }
 
-func (r *UserRepository) FindByID(id uuid.UUID) (*models.User, error) {
+func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
     var user models.User
-    err := r.DB.Model(&models.User{}).First(&user, "id = ?", id).Error
+    err := conn(ctx, r.DB).Model(&models.User{}).First(&user, "id = ?", id).Error
 
     if err != nil {
         if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -39,9 +55,9 @@ func (r *UserRepository) FindByID(id uuid.UUID) (*models.User, error) {
     return &user, nil
 }
 
-func (r *UserRepository) FindByEmail(email string) (*models.User, error) {
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
     var user models.User
-    err := r.DB.Model(&models.User{}).Where("email = ?", email).First(&user).Error
+    err := conn(ctx, r.DB).Model(&models.User{}).Where("email = ?", email).First(&user).Error
 
     if err != nil {
         if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -53,9 +69,9 @@ func (r *UserRepository) FindByEmail(email string) (*models.User, error) {
     return &user, nil
 }
 
-func (r *UserRepository) FindByEmailOrUsername(email, username string) (*models.User, error) {
+func (r *userRepository) FindByEmailOrUsername(ctx context.Context, email, username string) (*models.User, error) {
     var user models.User
-    err := r.DB.Model(&models.User{}).Where("email = ? OR name = ?", email, username).First(&user).Error
+    err := conn(ctx, r.DB).Model(&models.User{}).Where("email = ? OR name = ?", email, username).First(&user).Error
 
     if errors.Is(err, gorm.ErrRecordNotFound) {
         return nil, nil
@@ -63,16 +79,16 @@ func (r *UserRepository) FindByEmailOrUsername(email, username string) (*models.
     return &user, err
 }
 
-func (r *UserRepository) Update(user *models.User) error {
-    err := r.DB.Save(user).Error
+func (r *userRepository) Update(ctx context.Context, user *models.User) error {
+    err := conn(ctx, r.DB).Save(user).Error
     if err != nil {
         return err
     }
     return nil
 }
 
-func (r *UserRepository) Delete(id uuid.UUID) error {
-    tx := r.DB.Delete(&models.User{}, "id = ?", id)
+func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
+    tx := conn(ctx, r.DB).Delete(&models.User{}, "id = ?", id)
     err := tx.Error
     if err != nil {
         return err
@@ -85,9 +101,9 @@ func (r *UserRepository) Delete(id uuid.UUID) error {
     return nil
 }
 
-func (r *UserRepository) List(limit, offset int) ([]*models.User, error) {
+func (r *userRepository) List(ctx context.Context, limit, offset int) ([]*models.User, error) {
     var users []*models.User
-    err := r.DB.Limit(limit).Offset(offset).Find(&users).Error
+    err := conn(ctx, r.DB).Limit(limit).Offset(offset).Find(&users).Error
     if err != nil {
         return nil, err
     }
@@ -95,9 +111,9 @@ func (r *UserRepository) List(limit, offset int) ([]*models.User, error) {
     return users, nil
 }
 
-func (r *UserRepository) Count() (int64, error) {
+func (r *userRepository) Count(ctx context.Context) (int64, error) {
     var count int64
-    err := r.DB.Model(&models.User{}).Count(&count).Error
+    err := conn(ctx, r.DB).Model(&models.User{}).Count(&count).Error
     if err != nil {
         return 0, err
     }
@@ -105,9 +121,9 @@ func (r *UserRepository) Count() (int64, error) {
     return count, nil
 }
 
-func (r *UserRepository) MarkEmailVerified(userId uuid.UUID) error {
+func (r *userRepository) MarkEmailVerified(ctx context.Context, userId uuid.UUID) error {
     now := time.Now()
-    err := r.DB.Model(&models.User{}).Where("id = ?", userId).Updates(map[string]interface{}{
+    err := conn(ctx, r.DB).Model(&models.User{}).Where("id = ?", userId).Updates(map[string]interface{}{
         "is_email_verified": true,
         "email_verified_at": &now,
     }).Error
@@ -117,10 +133,41 @@ func (r *UserRepository) MarkEmailVerified(userId uuid.UUID) error {
     return nil
 }
 
-func (r *UserRepository) ChangePassword (hashedPassword string, userId uuid.UUID) error {
-    err := r.DB.Model(&models.User{}).Where("id = ?", userId).Update("password", hashedPassword).Error
+func (r *userRepository) ChangePassword(ctx context.Context, hashedPassword string, userId uuid.UUID) error {
+    err := conn(ctx, r.DB).Model(&models.User{}).Where("id = ?", userId).Update("password", hashedPassword).Error
     if err != nil {
         return err
     } 
     return nil
-}
\ No newline at end of file
+}
+
+// ListDigestSubscribers returns verified users opted in to the weekly or
+// monthly digest, ordered by id and starting after afterID (keyset paging).
+func (r *userRepository) ListDigestSubscribers(ctx context.Context, periodType models.PeriodType, afterID uuid.UUID, limit int) ([]*models.User, error) {
+    column := "digest_monthly"
+    if periodType == models.PeriodWeekly {
+        column = "digest_weekly"
+    }
+
+    var users []*models.User
+    err := conn(ctx, r.DB).Model(&models.User{}).
+        Where(column+" = ? AND is_email_verified = ?", true, true).
+        Where("id > ?", afterID).
+        Order("id ASC").
+        Limit(limit).
+        Find(&users).Error
+    if err != nil {
+        return nil, err
+    }
+
+    return users, nil
+}
+
+func (r *userRepository) UpdatePreferences(ctx context.Context, userId uuid.UUID, locale string, digestWeekly, digestMonthly bool) error {
+    return conn(ctx, r.DB).Model(&models.User{}).Where("id = ?", userId).Updates(map[string]interface{}{
+        "locale":         locale,
+        "digest_weekly":  digestWeekly,
+        "digest_monthly": digestMonthly,
+        "updated_at":     time.Now(),
+    }).Error
+}
@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"errors"
 	"gin-backend-app/internal/models"
 	"gin-backend-app/pkg/utils"
@@ -10,54 +11,75 @@ import (
 	"gorm.io/gorm"
 )
 
-type UserTokenRepository struct {
+var (
+	// ErrInvalidOTP is returned when no unexpired token matches the OTP, or
+	// its verify token was already generated.
+	ErrInvalidOTP = errors.New("invalid or expired otp, or verify token already generated")
+	// ErrInvalidVerifyToken is returned for unknown, expired or used
+	// password reset verify tokens.
+	ErrInvalidVerifyToken = errors.New("invalid or expired verification token")
+)
+
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *models.UserToken) error
+	FindTokenByOTP(ctx context.Context, otp string, tokenType models.TokenType, userId uuid.UUID) (*models.UserToken, error)
+	FindTokenByUserId(ctx context.Context, userId uuid.UUID) (*models.UserToken, error)
+	MarkAsUsedToken(ctx context.Context, tokenID uuid.UUID) error
+	CheckUserTokenCreatedAt(ctx context.Context, userId uuid.UUID) bool
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+	GenerateAndSetVerificationTokenByOTP(ctx context.Context, otp, email string, tokenType models.TokenType, userId uuid.UUID) (string, error)
+	ValidateTokenAndGetUser(ctx context.Context, verificationToken string) (*models.User, error)
+	MarkVerifyTokenUsed(ctx context.Context, verificationToken string) error
+}
+
+type userTokenRepository struct {
     DB *gorm.DB
 }
 
-func NewUserTokenRepository(db *gorm.DB) *UserTokenRepository {
-    return &UserTokenRepository{
+func NewUserTokenRepository(db *gorm.DB) UserTokenRepository {
+    return &userTokenRepository{
         DB: db,
     }
 }
 
-func (r *UserTokenRepository) Create(token *models.UserToken) error {
-    err := r.DB.Model(&models.UserToken{}).Create(token).Error
+func (r *userTokenRepository) Create(ctx context.Context, token *models.UserToken) error {
+    err := conn(ctx, r.DB).Model(&models.UserToken{}).Create(token).Error
     if err != nil {
         return err
     }
     return nil
 }
 
-func (r *UserTokenRepository) FindTokenByOTP(otp string, tokenType models.TokenType, userId uuid.UUID) (*models.UserToken, error) {
+func (r *userTokenRepository) FindTokenByOTP(ctx context.Context, otp string, tokenType models.TokenType, userId uuid.UUID) (*models.UserToken, error) {
     var token models.UserToken
-    err := r.DB.Model(&models.UserToken{}).Where("token_otp = ? AND token_type = ? AND user_id = ?", otp, tokenType, userId).First(&token).Error
+    err := conn(ctx, r.DB).Model(&models.UserToken{}).Where("token_otp = ? AND token_type = ? AND user_id = ?", otp, tokenType, userId).First(&token).Error
     if err != nil {
         return nil, err
     }
     return &token, nil
 }
 
-func (r *UserTokenRepository) FindTokenByUserId(userId uuid.UUID) (*models.UserToken, error) {
+func (r *userTokenRepository) FindTokenByUserId(ctx context.Context, userId uuid.UUID) (*models.UserToken, error) {
     var token models.UserToken
-    err := r.DB.Model(&models.UserToken{}).Where("user_id = ?", userId).First(&token).Error
+    err := conn(ctx, r.DB).Model(&models.UserToken{}).Where("user_id = ?", userId).First(&token).Error
     if err != nil {
         return nil, err
     }
     return &token, nil
 }
 
-func (r *UserTokenRepository) MarkAsUsedToken(tokenID uuid.UUID) error {
+func (r *userTokenRepository) MarkAsUsedToken(ctx context.Context, tokenID uuid.UUID) error {
     now := time.Now()
-    err := r.DB.Model(&models.UserToken{}).
+    err := conn(ctx, r.DB).Model(&models.UserToken{}).
         Where("id = ?", tokenID).
         Update("used_at", &now).Error
     return err
 }
 
-func (r *UserTokenRepository) CheckUserTokenCreatedAt(userId uuid.UUID) bool {
+func (r *userTokenRepository) CheckUserTokenCreatedAt(ctx context.Context, userId uuid.UUID) bool {
     oneMinuteAgo := time.Now().Add(-1 * time.Minute)
     var token models.UserToken
-    err := r.DB.Model(&models.UserToken{}).
+    err := conn(ctx, r.DB).Model(&models.UserToken{}).
         Where("user_id = ? AND token_type = ? AND created_at >= ?", userId, models.TokenTypeEmailVerification, oneMinuteAgo).
         Order("created_at DESC").
         First(&token).Error
@@ -71,9 +93,9 @@ func (r *UserTokenRepository) CheckUserTokenCreatedAt(userId uuid.UUID) bool {
     return false
 }
 
-func (r *UserTokenRepository) DeleteExpired(now time.Time) (int64, error) {
+func (r *userTokenRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
     // Need to get RowsAffected before getting Error
-    tx := r.DB.Model(&models.UserToken{}).
+    tx := conn(ctx, r.DB).Model(&models.UserToken{}).
         Where("expires_at < ? AND used_at IS NULL", now).
         Delete(&models.UserToken{})
     
@@ -84,13 +106,13 @@ func (r *UserTokenRepository) DeleteExpired(now time.Time) (int64, error) {
     return tx.RowsAffected, nil
 }
 
-func (r *UserTokenRepository) GenerateAndSetVerificationTokenByOTP(otp, email string, tokenType models.TokenType, userId uuid.UUID) (string, error) {
+func (r *userTokenRepository) GenerateAndSetVerificationTokenByOTP(ctx context.Context, otp, email string, tokenType models.TokenType, userId uuid.UUID) (string, error) {
     verifyToken, err := utils.GenerateRandomToken(32)
     if err != nil {
         return "", err
     }
 
-	tx := r.DB.Model(&models.UserToken{}).
+	tx := conn(ctx, r.DB).Model(&models.UserToken{}).
         Where("user_id = ?", userId).
         Where("token_otp = ?", otp).
         Where("token_type = ?", tokenType).
@@ -104,16 +126,17 @@ func (r *UserTokenRepository) GenerateAndSetVerificationTokenByOTP(otp, email st
     }
 
     if tx.RowsAffected == 0 {
-        return "", errors.New("invalid or expired otp, or verify token already generated")
+        return "", ErrInvalidOTP
     }
 
     return verifyToken, nil
 }
 
-func (r *UserTokenRepository) ValidateTokenAndGetUser (verificationToken string) (*models.User, error) {
+func (r *userTokenRepository) ValidateTokenAndGetUser(ctx context.Context, verificationToken string) (*models.User, error) {
     var token models.UserToken
 
-    err := r.DB.
+    err := conn(ctx, r.DB).
+        Preload("User").
         Where("verify_token = ?", verificationToken).
         Where("expires_at > ?", time.Now()).
         Where("used_at IS NULL").
@@ -121,9 +144,26 @@ func (r *UserTokenRepository) ValidateTokenAndGetUser (verificationToken string)
 
     if err != nil {
         if errors.Is(err, gorm.ErrRecordNotFound) {
-            return nil ,errors.New("invalid or expired verification token")
+            return nil ,ErrInvalidVerifyToken
         }
-        return &token.User, nil
+        return nil, err
     }
     return &token.User, nil
+}
+
+// MarkVerifyTokenUsed consumes a password reset verify token. It fails when
+// the token was already used, so two concurrent resets cannot both succeed.
+func (r *userTokenRepository) MarkVerifyTokenUsed(ctx context.Context, verificationToken string) error {
+    now := time.Now()
+    tx := conn(ctx, r.DB).Model(&models.UserToken{}).
+        Where("verify_token = ? AND used_at IS NULL", verificationToken).
+        Update("used_at", &now)
+
+    if tx.Error != nil {
+        return tx.Error
+    }
+    if tx.RowsAffected == 0 {
+        return ErrInvalidVerifyToken
+    }
+    return nil
 }
\ No newline at end of file
@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"gin-backend-app/internal/app"
+	"gin-backend-app/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAttachmentRoutes(api *gin.RouterGroup, c *app.Controllers) {
+	transactionAttachments := api.Group("/transactions/:id/attachments")
+	transactionAttachments.Use(middleware.AuthMiddleware())
+	{
+		transactionAttachments.GET("", c.Attachment.ListAttachments)
+		transactionAttachments.POST("", c.Attachment.UploadAttachment)
+	}
+
+	// Downloads are authorized by the URL signature so they work in <img> tags.
+	api.GET("/attachments/:id/content", c.Attachment.DownloadAttachment)
+
+	attachments := api.Group("/attachments")
+	attachments.Use(middleware.AuthMiddleware())
+	{
+		attachments.GET("/:id", c.Attachment.GetAttachment)
+		attachments.DELETE("/:id", c.Attachment.DeleteAttachment)
+	}
+}
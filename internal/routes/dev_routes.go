@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"gin-backend-app/internal/app"
+	"gin-backend-app/internal/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupDevRoutes registers development helpers. They are skipped entirely
+// when ENV=production.
+func SetupDevRoutes(api *gin.RouterGroup, cfg *config.Config, c *app.Controllers) {
+	if cfg.IsProduction() {
+		return
+	}
+
+	dev := api.Group("/dev")
+	{
+		dev.GET("/emails", c.EmailPreview.ListEmailTemplates)
+		dev.GET("/emails/:name", c.EmailPreview.PreviewEmail)
+	}
+}
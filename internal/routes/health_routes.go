@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"gin-backend-app/internal/app"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupHealthRoutes registers the probes outside /api/v1, without auth or
+// request timeout. /health stays as an alias of /livez for existing
+// container health checks.
+func SetupHealthRoutes(router *gin.Engine, c *app.Controllers) {
+	router.GET("/livez", c.Health.Livez)
+	router.GET("/readyz", c.Health.Readyz)
+	router.GET("/health", c.Health.Livez)
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"gin-backend-app/internal/app"
+	"gin-backend-app/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupNotificationRoutes serves notifications; the controller's hub must be
+// fed by a realtime.Listener started by the caller.
+func SetupNotificationRoutes(api *gin.RouterGroup, c *app.Controllers) {
+	notifications := api.Group("/notifications")
+	notifications.Use(middleware.AuthMiddleware())
+	{
+		notifications.GET("", c.Notification.ListNotifications)
+		notifications.GET("/stream", c.Notification.StreamNotifications)
+		notifications.POST("/read-all", c.Notification.MarkAllNotificationsRead)
+		notifications.PATCH("/:id/read", c.Notification.MarkNotificationRead)
+	}
+}
@@ -1,11 +1,32 @@
 package routes
 
 import (
+	"gin-backend-app/internal/app"
+	"gin-backend-app/internal/middleware"
+
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
-func SetupRoutes(router *gin.Engine, db *gorm.DB) {
+// SetupRoutes registers every route on controllers taken from a; nothing is
+// constructed here.
+func SetupRoutes(router *gin.Engine, a *app.App) {
+	SetupHealthRoutes(router, a.Controllers)
+
 	api := router.Group("/api/v1")
-	SetupUserRoutes(api, db)
-}
\ No newline at end of file
+	// ErrorMiddleware is outermost so a timeout response written by
+	// TimeoutMiddleware is not overwritten by the handler's error.
+	api.Use(middleware.ErrorMiddleware())
+	// Streamed responses last as long as the client reads them.
+	api.Use(middleware.TimeoutMiddleware(a.Config.HTTP.RequestTimeout,
+		"/api/v1/notifications/stream",
+		"/api/v1/transactions/export",
+		"/api/v1/attachments/:id/content",
+	))
+	SetupUserRoutes(api, a.Controllers)
+	SetupSavingsGoalRoutes(api, a.Controllers)
+	SetupTransactionRoutes(api, a.Controllers)
+	SetupTagRoutes(api, a.Controllers)
+	SetupAttachmentRoutes(api, a.Controllers)
+	SetupNotificationRoutes(api, a.Controllers)
+	SetupDevRoutes(api, a.Config, a.Controllers)
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"gin-backend-app/internal/app"
+	"gin-backend-app/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupSavingsGoalRoutes(api *gin.RouterGroup, c *app.Controllers) {
+	goals := api.Group("/goals")
+	goals.Use(middleware.AuthMiddleware())
+	{
+		goals.GET("", c.SavingsGoal.ListGoals)
+		goals.POST("", c.SavingsGoal.CreateGoal)
+		goals.GET("/:id", c.SavingsGoal.GetGoal)
+		goals.PUT("/:id", c.SavingsGoal.UpdateGoal)
+		goals.DELETE("/:id", c.SavingsGoal.DeleteGoal)
+	}
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"gin-backend-app/internal/app"
+	"gin-backend-app/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTagRoutes(api *gin.RouterGroup, c *app.Controllers) {
+	tags := api.Group("/tags")
+	tags.Use(middleware.AuthMiddleware())
+	{
+		tags.GET("", c.Tag.ListTags)
+		tags.POST("", c.Tag.CreateTag)
+		tags.GET("/spending", c.Tag.GetTagSpending)
+		tags.PUT("/:id", c.Tag.UpdateTag)
+		tags.DELETE("/:id", c.Tag.DeleteTag)
+	}
+}
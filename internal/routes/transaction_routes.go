@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"gin-backend-app/internal/app"
+	"gin-backend-app/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTransactionRoutes(api *gin.RouterGroup, c *app.Controllers) {
+	transactions := api.Group("/transactions")
+	transactions.Use(middleware.AuthMiddleware())
+	{
+		transactions.GET("", c.Transaction.ListTransactions)
+		transactions.POST("", c.Transaction.CreateTransaction)
+		transactions.POST("/import", c.Transaction.ImportTransactions)
+		transactions.GET("/export", c.Transaction.ExportTransactions)
+		transactions.GET("/:id", c.Transaction.GetTransaction)
+		transactions.PUT("/:id", c.Transaction.UpdateTransaction)
+		transactions.DELETE("/:id", c.Transaction.DeleteTransaction)
+	}
+
+	reports := api.Group("/reports")
+	reports.Use(middleware.AuthMiddleware())
+	{
+		reports.GET("/:period_type", c.Report.GetPeriodReport)
+	}
+
+	budgets := api.Group("/budgets")
+	budgets.Use(middleware.AuthMiddleware())
+	{
+		budgets.GET("/status", c.Report.GetBudgetStatus)
+		budgets.POST("/evaluation", c.Report.EvaluateBudgets)
+	}
+}
@@ -2,54 +2,38 @@ package routes
 
 import (
 	_ "gin-backend-app/cmd/server/docs"
-	"gin-backend-app/internal/controllers"
+	"gin-backend-app/internal/app"
 	"gin-backend-app/internal/middleware"
-	"gin-backend-app/internal/repositories"
-	"gin-backend-app/internal/services"
-	"gin-backend-app/pkg/utils"
-	"os"
-
-	"gorm.io/gorm"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupUserRoutes(api *gin.RouterGroup, db *gorm.DB) {
-	baseURL := os.Getenv("BASE_URL")
-	if baseURL == "" {
-		baseURL = "http://localhost:8080"
-	}
-
-	// Initialize mailer
-	mailer := utils.NewSMTPMailerFromEnv()
-
-	userRepo := repositories.NewUserRepository(db)
-	userEmailVerificationRepo := repositories.NewUserTokenRepository(db)
-
-	// Fix the service initialization - pass the mailer and baseURL
-	userEmailVerificationService := services.NewEmailVerificationService(userRepo, userEmailVerificationRepo, mailer, baseURL)
-
-	userService := services.NewUserService(userRepo, userEmailVerificationRepo, userEmailVerificationService)
-	userController := controllers.NewUserController(userService)
-	userEmailVerificationController := controllers.NewEmailVerificationController(userEmailVerificationService)
-
+func SetupUserRoutes(api *gin.RouterGroup, c *app.Controllers) {
 	auth := api.Group("/auth")
 	{
-		auth.POST("/register", userController.RegisterUser)
-		auth.POST("/login", userController.LoginUser)
+		auth.POST("/register", c.User.RegisterUser)
+		auth.POST("/login", c.User.LoginUser)
 		
-		auth.POST("/request-change-password", userController.SendEmailPasswordReset)
-		auth.POST("/verify-otp-password-change", userController.GenerateAndSetVerificationToken)
-		auth.POST("/change-password", userController.ValidateAndChangePassword)
+		auth.POST("/request-change-password", c.User.SendEmailPasswordReset)
+		auth.POST("/verify-otp-password-change", c.User.GenerateAndSetVerificationToken)
+		auth.POST("/change-password", c.User.ValidateAndChangePassword)
 
 		protected := auth.Group("")
 		protected.Use(middleware.AuthMiddleware())
 		{
-			protected.POST("/verify-email", userEmailVerificationController.VerifyEmail)
-			protected.POST("/resend-verification", userEmailVerificationController.ResendEmailVerification)
-			protected.GET("/verification-status", userEmailVerificationController.CheckVerificationStatus)
+			protected.POST("/verify-email", c.EmailVerification.VerifyEmail)
+			protected.POST("/resend-verification", c.EmailVerification.ResendEmailVerification)
+			protected.GET("/verification-status", c.EmailVerification.CheckVerificationStatus)
 
 
 		}
 	}
+
+	users := api.Group("/users")
+	users.Use(middleware.AuthMiddleware())
+	{
+		users.GET("/me/preferences", c.User.GetPreferences)
+		users.PUT("/me/preferences", c.User.UpdatePreferences)
+		users.PUT("/me/password", c.User.UpdatePassword)
+	}
 }
\ No newline at end of file
@@ -0,0 +1,319 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"gin-backend-app/internal/apperror"
+	"gin-backend-app/internal/dto/response"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"gin-backend-app/pkg/storage"
+	"gin-backend-app/pkg/utils"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	AttachmentVariantOriginal  = "original"
+	AttachmentVariantThumbnail = "thumbnail"
+
+	maxAttachmentsPerTransaction = 10
+	thumbnailMaxDim              = 320
+)
+
+var (
+	ErrAttachmentNotFound  = apperror.New(apperror.CodeAttachmentNotFound, "attachment not found")
+	ErrAttachmentTooLarge  = apperror.New(apperror.CodeAttachmentTooLarge, "attachment exceeds the maximum file size")
+	ErrInvalidSignature    = apperror.New(apperror.CodeAttachmentURLInvalid, "invalid or expired attachment url")
+	allowedAttachmentTypes = map[string]string{
+		"image/jpeg":      ".jpg",
+		"image/png":       ".png",
+		"image/webp":      ".webp",
+		"application/pdf": ".pdf",
+	}
+)
+
+type AttachmentConfig struct {
+	// MaxSize is the largest accepted upload in bytes.
+	MaxSize int64
+	// URLTTL is how long signed download URLs stay valid.
+	URLTTL time.Duration
+	// SigningKey signs download URLs.
+	SigningKey []byte
+	// BaseURL is the public origin used to build download URLs.
+	BaseURL string
+}
+
+type AttachmentService struct {
+	AttachmentRepo  repositories.AttachmentRepository
+	TransactionRepo repositories.TransactionRepository
+	Storage         storage.Storage
+	Config          AttachmentConfig
+}
+
+func NewAttachmentService(attachmentRepo repositories.AttachmentRepository, transactionRepo repositories.TransactionRepository, store storage.Storage, cfg AttachmentConfig) *AttachmentService {
+	return &AttachmentService{AttachmentRepo: attachmentRepo, TransactionRepo: transactionRepo, Storage: store, Config: cfg}
+}
+
+// Upload validates the file by sniffing its content (the client supplied
+// Content-Type is ignored), stores it and, for images, a JPEG thumbnail.
+func (s *AttachmentService) Upload(ctx context.Context, userId, transactionId uuid.UUID, fileName string, file io.Reader) (*response.AttachmentResponse, error) {
+	transaction, err := s.TransactionRepo.FindByID(ctx, transactionId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if transaction == nil {
+		return nil, ErrTransactionNotFound
+	}
+
+	count, err := s.AttachmentRepo.CountByTransaction(ctx, transactionId)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxAttachmentsPerTransaction {
+		return nil, apperror.Field("file", "max", fmt.Sprintf("a transaction can have at most %d attachments", maxAttachmentsPerTransaction))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(file, s.Config.MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	if int64(len(data)) > s.Config.MaxSize {
+		return nil, ErrAttachmentTooLarge
+	}
+	if len(data) == 0 {
+		return nil, apperror.Field("file", "required", "attachment is empty")
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := allowedAttachmentTypes[contentType]
+	if !ok {
+		return nil, apperror.Field("file", "mimetype", fmt.Sprintf("unsupported attachment type %s, only JPEG, PNG, WebP and PDF are allowed", contentType))
+	}
+
+	attachment := &models.Attachment{
+		ID:            uuid.New(),
+		UserID:        userId,
+		TransactionID: transactionId,
+		FileName:      sanitizeFileName(fileName, ext),
+		ContentType:   contentType,
+		Size:          int64(len(data)),
+	}
+	prefix := fmt.Sprintf("attachments/%s/%s/%s", userId, transactionId, attachment.ID)
+	attachment.StorageKey = prefix + ext
+
+	if err := s.Storage.Put(ctx, attachment.StorageKey, bytes.NewReader(data), attachment.Size, contentType); err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	if contentType != "application/pdf" {
+		thumbnail, err := utils.GenerateThumbnail(bytes.NewReader(data), thumbnailMaxDim)
+		if err != nil {
+			// A receipt that cannot be decoded is still worth keeping.
+			slog.WarnContext(ctx, "thumbnail generation failed", "attachment_id", attachment.ID, "error", err)
+		} else {
+			thumbnailKey := prefix + "_thumb.jpg"
+			if err := s.Storage.Put(ctx, thumbnailKey, bytes.NewReader(thumbnail), int64(len(thumbnail)), "image/jpeg"); err != nil {
+				slog.ErrorContext(ctx, "failed to store thumbnail", "attachment_id", attachment.ID, "error", err)
+			} else {
+				attachment.ThumbnailKey = &thumbnailKey
+			}
+		}
+	}
+
+	if err := s.AttachmentRepo.Create(ctx, attachment); err != nil {
+		s.removeObjects(ctx, attachment)
+		return nil, fmt.Errorf("failed to save attachment: %w", err)
+	}
+
+	res := s.toAttachmentResponse(attachment, time.Now())
+	return &res, nil
+}
+
+func (s *AttachmentService) ListAttachments(ctx context.Context, userId, transactionId uuid.UUID) ([]response.AttachmentResponse, error) {
+	transaction, err := s.TransactionRepo.FindByID(ctx, transactionId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if transaction == nil {
+		return nil, ErrTransactionNotFound
+	}
+
+	attachments, err := s.AttachmentRepo.ListByTransaction(ctx, transactionId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]response.AttachmentResponse, 0, len(attachments))
+	for _, attachment := range attachments {
+		result = append(result, s.toAttachmentResponse(attachment, now))
+	}
+	return result, nil
+}
+
+// GetAttachment returns the attachment with freshly signed download URLs.
+func (s *AttachmentService) GetAttachment(ctx context.Context, userId, attachmentId uuid.UUID) (*response.AttachmentResponse, error) {
+	attachment, err := s.findOwned(ctx, userId, attachmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	res := s.toAttachmentResponse(attachment, time.Now())
+	return &res, nil
+}
+
+// OpenSigned verifies a signed download URL and opens the requested variant.
+// It does not need an authenticated user: possession of a valid, unexpired
+// signature issued to the owner is the authorization.
+func (s *AttachmentService) OpenSigned(ctx context.Context, attachmentId uuid.UUID, variant string, expiresUnix int64, signature string) (io.ReadCloser, *models.Attachment, string, error) {
+	attachment, err := s.AttachmentRepo.FindByID(ctx, attachmentId)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if attachment == nil {
+		return nil, nil, "", ErrInvalidSignature
+	}
+
+	expires := time.Unix(expiresUnix, 0)
+	if !utils.VerifyResourceSignature(s.Config.SigningKey, signature, expires, attachment.ID.String(), attachment.UserID.String(), variant) {
+		return nil, nil, "", ErrInvalidSignature
+	}
+
+	key, contentType := attachment.StorageKey, attachment.ContentType
+	if variant == AttachmentVariantThumbnail {
+		if attachment.ThumbnailKey == nil {
+			return nil, nil, "", ErrAttachmentNotFound
+		}
+		key, contentType = *attachment.ThumbnailKey, "image/jpeg"
+	}
+
+	reader, err := s.Storage.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, nil, "", ErrAttachmentNotFound
+		}
+		return nil, nil, "", err
+	}
+	return reader, attachment, contentType, nil
+}
+
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, userId, attachmentId uuid.UUID) error {
+	attachment, err := s.findOwned(ctx, userId, attachmentId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.AttachmentRepo.Delete(ctx, attachment.ID, userId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAttachmentNotFound
+		}
+		return err
+	}
+
+	s.removeObjects(ctx, attachment)
+	return nil
+}
+
+func (s *AttachmentService) findOwned(ctx context.Context, userId, attachmentId uuid.UUID) (*models.Attachment, error) {
+	attachment, err := s.AttachmentRepo.FindByID(ctx, attachmentId)
+	if err != nil {
+		return nil, err
+	}
+	if attachment == nil || attachment.UserID != userId {
+		return nil, ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// ListForTransaction returns the attachments of a transaction, so their
+// objects can be removed once the transaction is deleted.
+func (s *AttachmentService) ListForTransaction(ctx context.Context, userId, transactionId uuid.UUID) ([]*models.Attachment, error) {
+	return s.AttachmentRepo.ListByTransaction(ctx, transactionId, userId)
+}
+
+// ListForUser returns every attachment of a user, so their objects can be
+// removed once the user is deleted.
+func (s *AttachmentService) ListForUser(ctx context.Context, userId uuid.UUID) ([]*models.Attachment, error) {
+	return s.AttachmentRepo.ListByUser(ctx, userId)
+}
+
+// RemoveObjects deletes the stored files of attachments whose rows were
+// removed by the cascade of their transaction or user. Call it after the
+// delete is committed.
+func (s *AttachmentService) RemoveObjects(ctx context.Context, attachments []*models.Attachment) {
+	for _, attachment := range attachments {
+		s.removeObjects(ctx, attachment)
+	}
+}
+
+// removeObjects deletes the stored files of an attachment whose row is gone.
+// It outlives a cancelled request so no orphaned objects are left behind.
+func (s *AttachmentService) removeObjects(ctx context.Context, attachment *models.Attachment) {
+	ctx = context.WithoutCancel(ctx)
+	if err := s.Storage.Delete(ctx, attachment.StorageKey); err != nil {
+		slog.ErrorContext(ctx, "failed to delete attachment object", "key", attachment.StorageKey, "error", err)
+	}
+	if attachment.ThumbnailKey != nil {
+		if err := s.Storage.Delete(ctx, *attachment.ThumbnailKey); err != nil {
+			slog.ErrorContext(ctx, "failed to delete attachment object", "key", *attachment.ThumbnailKey, "error", err)
+		}
+	}
+}
+
+func (s *AttachmentService) signedURL(attachment *models.Attachment, variant string, expires time.Time) string {
+	signature := utils.SignResource(s.Config.SigningKey, expires, attachment.ID.String(), attachment.UserID.String(), variant)
+
+	query := url.Values{}
+	query.Set("variant", variant)
+	query.Set("expires", strconv.FormatInt(expires.Unix(), 10))
+	query.Set("signature", signature)
+
+	return fmt.Sprintf("%s/api/v1/attachments/%s/content?%s", s.Config.BaseURL, attachment.ID, query.Encode())
+}
+
+func (s *AttachmentService) toAttachmentResponse(attachment *models.Attachment, now time.Time) response.AttachmentResponse {
+	expires := now.Add(s.Config.URLTTL)
+
+	res := response.AttachmentResponse{
+		ID:            attachment.ID,
+		TransactionID: attachment.TransactionID,
+		FileName:      attachment.FileName,
+		ContentType:   attachment.ContentType,
+		Size:          attachment.Size,
+		URL:           s.signedURL(attachment, AttachmentVariantOriginal, expires),
+		URLExpiresAt:  expires,
+		CreatedAt:     attachment.CreatedAt,
+	}
+	if attachment.ThumbnailKey != nil {
+		thumbnailURL := s.signedURL(attachment, AttachmentVariantThumbnail, expires)
+		res.ThumbnailURL = &thumbnailURL
+	}
+	return res
+}
+
+// sanitizeFileName keeps only the base name of the uploaded file and
+// replaces its extension with ext, the one matching the sniffed content
+// type, so the name served back never claims a different type.
+func sanitizeFileName(name, ext string) string {
+	base := filepath.Base(filepath.Clean("/" + name))
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+	if base == "/" || stem == "" {
+		stem = "receipt"
+	}
+	if runes := []rune(stem); len(runes) > 200 {
+		stem = string(runes[:200])
+	}
+	return stem + ext
+}
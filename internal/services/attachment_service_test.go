@@ -0,0 +1,32 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeFileName(t *testing.T) {
+	long := strings.Repeat("é", 250)
+	tests := []struct {
+		name string
+		in   string
+		ext  string
+		want string
+	}{
+		{"keeps matching extension", "receipt.png", ".png", "receipt.png"},
+		{"replaces claimed extension", "evil.html", ".png", "evil.png"},
+		{"replaces only the last extension", "scan.pdf.html", ".jpg", "scan.pdf.jpg"},
+		{"adds missing extension", "receipt", ".pdf", "receipt.pdf"},
+		{"strips directories", "../../etc/passwd", ".png", "passwd.png"},
+		{"names dotfiles", ".htaccess", ".png", "receipt.png"},
+		{"names empty input", "", ".jpg", "receipt.jpg"},
+		{"truncates by rune", long + ".txt", ".png", strings.Repeat("é", 200) + ".png"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeFileName(tt.in, tt.ext); got != tt.want {
+				t.Errorf("sanitizeFileName(%q, %q) = %q, want %q", tt.in, tt.ext, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"gin-backend-app/pkg/mailtemplate"
+	"gin-backend-app/pkg/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultBudgetAlertThresholds are the budget usage percentages that trigger
+// an alert when no thresholds are configured.
+var DefaultBudgetAlertThresholds = []int{50, 80, 100}
+
+type BudgetAlertService struct {
+	Tx            repositories.TxManager
+	BudgetService *BudgetService
+	AlertRepo     repositories.BudgetAlertRepository
+	UserRepo      repositories.UserRepository
+	Notifications *NotificationService
+	Outbox        *EmailOutboxService
+	// Thresholds are usage percentages in ascending order.
+	Thresholds []int
+}
+
+func NewBudgetAlertService(tx repositories.TxManager, budgetService *BudgetService, alertRepo repositories.BudgetAlertRepository, userRepo repositories.UserRepository, notifications *NotificationService, outbox *EmailOutboxService, thresholds []int) *BudgetAlertService {
+	if len(thresholds) == 0 {
+		thresholds = DefaultBudgetAlertThresholds
+	}
+	return &BudgetAlertService{
+		Tx:            tx,
+		BudgetService: budgetService,
+		AlertRepo:     alertRepo,
+		UserRepo:      userRepo,
+		Notifications: notifications,
+		Outbox:        outbox,
+		Thresholds:    thresholds,
+	}
+}
+
+// CheckTransactions evaluates the budgets touched by the given expense
+// transactions (including split categories) and alerts on every threshold
+// crossed for the first time in the budget period.
+func (s *BudgetAlertService) CheckTransactions(ctx context.Context, userId uuid.UUID, transactions []*models.Transaction) error {
+	categoriesByDate := make(map[time.Time]map[uuid.UUID]bool)
+	for _, transaction := range transactions {
+		if transaction.Type != models.TransactionGroupExpense {
+			continue
+		}
+		date := truncateToDate(transaction.Date)
+		categories, ok := categoriesByDate[date]
+		if !ok {
+			categories = make(map[uuid.UUID]bool)
+			categoriesByDate[date] = categories
+		}
+		categories[transaction.CategoryID] = true
+		for _, split := range transaction.Splits {
+			categories[split.CategoryID] = true
+		}
+	}
+	if len(categoriesByDate) == 0 {
+		return nil
+	}
+
+	user, err := s.UserRepo.FindByID(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("failed to load user: %w", err)
+	}
+	if user == nil {
+		return nil
+	}
+
+	type budgetPeriod struct {
+		budgetID    uuid.UUID
+		periodStart time.Time
+	}
+	checked := make(map[budgetPeriod]bool)
+
+	for date, categories := range categoriesByDate {
+		budgets, err := s.BudgetService.BudgetRepo.ListActiveByUser(ctx, userId, date)
+		if err != nil {
+			return fmt.Errorf("failed to load budgets: %w", err)
+		}
+
+		for _, budget := range budgets {
+			if !categories[budget.CategoryID] {
+				continue
+			}
+			status, err := s.BudgetService.budgetStatus(ctx, budget, date)
+			if err != nil {
+				return err
+			}
+
+			key := budgetPeriod{budgetID: budget.ID, periodStart: status.PeriodStart}
+			if checked[key] {
+				continue
+			}
+			checked[key] = true
+
+			if err := s.alert(ctx, user, budget, status.PeriodStart, status.PeriodEnd, status.Spent, status.PercentUsed); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// alert records every crossed threshold and, if any of them is new, sends a
+// single notification and email for the highest one, all in one transaction.
+func (s *BudgetAlertService) alert(ctx context.Context, user *models.User, budget *models.UserBudget, periodStart, periodEnd time.Time, spent, percentUsed float64) error {
+	var crossed []int
+	for _, threshold := range s.Thresholds {
+		if percentUsed >= float64(threshold) {
+			crossed = append(crossed, threshold)
+		}
+	}
+	if len(crossed) == 0 {
+		return nil
+	}
+
+	return s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		highest := 0
+		for _, threshold := range crossed {
+			created, err := s.AlertRepo.CreateIfAbsent(ctx, &models.BudgetAlert{
+				BudgetID:    budget.ID,
+				PeriodStart: periodStart,
+				Threshold:   threshold,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to record budget alert: %w", err)
+			}
+			if created {
+				highest = threshold
+			}
+		}
+		if highest == 0 {
+			return nil
+		}
+
+		data := map[string]any{
+			"Name":        user.Name,
+			"Category":    budget.Category.Name,
+			"Threshold":   highest,
+			"PercentUsed": fmt.Sprintf("%.0f", percentUsed),
+			"Spent":       fmt.Sprintf("%.2f", spent),
+			"Amount":      fmt.Sprintf("%.2f", budget.Amount),
+			"PeriodStart": periodStart.Format(dateLayout),
+			"PeriodEnd":   periodEnd.Format(dateLayout),
+		}
+
+		if err := s.Notifications.Create(ctx, &models.Notification{
+			UserID: user.ID,
+			Type:   models.NotificationBudgetThreshold,
+			Title:  mailtemplate.Translate(user.Locale, "budget_alert.title", budget.Category.Name, highest),
+			Body: mailtemplate.Translate(user.Locale, "budget_alert.body",
+				data["Spent"], data["Amount"], budget.Category.Name, data["PercentUsed"], data["PeriodStart"], data["PeriodEnd"]),
+			Data: map[string]any{
+				"budget_id":    budget.ID,
+				"category_id":  budget.CategoryID,
+				"threshold":    highest,
+				"percent_used": percentUsed,
+				"period_start": data["PeriodStart"],
+				"period_end":   data["PeriodEnd"],
+			},
+		}); err != nil {
+			return fmt.Errorf("failed to store notification: %w", err)
+		}
+
+		email, err := mailtemplate.Render("budget_alert", user.Locale, data)
+		if err != nil {
+			return fmt.Errorf("failed to render email: %w", err)
+		}
+		return s.Outbox.Enqueue(ctx, utils.Message{
+			To:      user.Email,
+			Subject: email.Subject,
+			HTML:    email.HTML,
+			Text:    email.Text,
+		})
+	})
+}
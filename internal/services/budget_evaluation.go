@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"gin-backend-app/internal/dto/response"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BudgetEvaluationService assembles the input payload stored in
+// AILog.InputData for the budget_evaluation analysis type.
+type BudgetEvaluationService struct {
+	BudgetService *BudgetService
+	GoalService   *SavingsGoalService
+	AILogRepo     repositories.AILogRepository
+}
+
+func NewBudgetEvaluationService(budgetService *BudgetService, goalService *SavingsGoalService, aiLogRepo repositories.AILogRepository) *BudgetEvaluationService {
+	return &BudgetEvaluationService{BudgetService: budgetService, GoalService: goalService, AILogRepo: aiLogRepo}
+}
+
+// Evaluate stores a budget_evaluation AILog whose input holds the user's
+// budget and savings goal status. OutputData stays empty until the analysis
+// has run.
+func (s *BudgetEvaluationService) Evaluate(ctx context.Context, userId uuid.UUID) (*response.BudgetEvaluationResponse, error) {
+	input, err := s.BuildInput(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	log := &models.AILog{
+		UserID:       userId,
+		AnalysisType: models.AiBudgetEvaluation,
+		InputData:    input,
+	}
+	if err := s.AILogRepo.Create(ctx, log); err != nil {
+		return nil, fmt.Errorf("failed to store budget evaluation: %w", err)
+	}
+
+	return &response.BudgetEvaluationResponse{
+		ID:           log.ID,
+		AnalysisType: string(log.AnalysisType),
+		InputData:    log.InputData,
+		OutputData:   log.OutputData,
+		CreatedAt:    log.CreatedAt,
+	}, nil
+}
+
+func (s *BudgetEvaluationService) BuildInput(ctx context.Context, userId uuid.UUID) (map[string]interface{}, error) {
+	budgets, err := s.BudgetService.GetBudgetStatus(ctx, userId, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load budget status: %w", err)
+	}
+
+	budgetInputs := make([]map[string]interface{}, 0, len(budgets))
+	for _, budget := range budgets {
+		budgetInputs = append(budgetInputs, map[string]interface{}{
+			"category":     budget.CategoryName,
+			"period_type":  budget.PeriodType,
+			"period_start": budget.PeriodStart.Format(dateLayout),
+			"period_end":   budget.PeriodEnd.Format(dateLayout),
+			"amount":       budget.Amount,
+			"spent":        budget.Spent,
+			"percent_used": budget.PercentUsed,
+			"is_exceeded":  budget.IsExceeded,
+		})
+	}
+
+	goals, err := s.GoalService.ListGoals(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load savings goals: %w", err)
+	}
+
+	goalInputs := make([]map[string]interface{}, 0, len(goals))
+	for _, goal := range goals {
+		entry := map[string]interface{}{
+			"name":                          goal.Name,
+			"target_amount":                 goal.TargetAmount,
+			"deadline":                      goal.Deadline.Format(dateLayout),
+			"contributed_amount":            goal.Progress.ContributedAmount,
+			"percent_complete":              goal.Progress.PercentComplete,
+			"required_monthly_contribution": goal.Progress.RequiredMonthlyContribution,
+			"average_monthly_contribution":  goal.Progress.AverageMonthlyContribution,
+			"status":                        goal.Progress.Status,
+		}
+		if goal.Progress.ProjectedCompletionDate != nil {
+			entry["projected_completion_date"] = goal.Progress.ProjectedCompletionDate.Format(dateLayout)
+		}
+		goalInputs = append(goalInputs, entry)
+	}
+
+	return map[string]interface{}{
+		"analysis_type": models.AiBudgetEvaluation,
+		"budgets":       budgetInputs,
+		"savings_goals": goalInputs,
+	}, nil
+}
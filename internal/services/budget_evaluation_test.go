@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories/fakes"
+	"gin-backend-app/internal/testutil/fixtures"
+
+	"github.com/google/uuid"
+)
+
+func TestEvaluateStoresBudgetAndGoalStatus(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Now()
+
+	category := fixtures.NewCategory(userID).Named("Food").Build()
+	categories := fakes.NewCategoryRepository(category)
+	transactions := fakes.NewTransactionRepository(categories)
+	budgets := fakes.NewUserBudgetRepository(categories, &models.UserBudget{
+		UserID:      userID,
+		CategoryID:  category.ID,
+		Amount:      100,
+		PeriodType:  "monthly",
+		PeriodValue: 1,
+		StartDate:   now.AddDate(0, -1, 0),
+		IsActive:    true,
+	})
+	goals := fakes.NewSavingsGoalRepository(categories)
+	if err := goals.Create(ctx, &models.SavingsGoal{
+		UserID:       userID,
+		Name:         "Holiday",
+		TargetAmount: 1000,
+		StartDate:    now.AddDate(0, -1, 0),
+		Deadline:     now.AddDate(1, 0, 0),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	logs := fakes.NewAILogRepository()
+	service := NewBudgetEvaluationService(
+		NewBudgetService(budgets, transactions),
+		NewSavingsGoalService(goals, categories, transactions),
+		logs,
+	)
+
+	evaluation, err := service.Evaluate(ctx, userID)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+
+	stored := logs.Logs()
+	if len(stored) != 1 {
+		t.Fatalf("stored %d logs, want 1", len(stored))
+	}
+	log := stored[0]
+	if log.ID != evaluation.ID || log.UserID != userID || log.AnalysisType != models.AiBudgetEvaluation {
+		t.Errorf("log = %+v, want a budget_evaluation log for the user", log)
+	}
+	if got := log.InputData["budgets"].([]map[string]interface{}); len(got) != 1 || got[0]["category"] != "Food" {
+		t.Errorf("budgets input = %v, want the Food budget", got)
+	}
+	goalInputs := log.InputData["savings_goals"].([]map[string]interface{})
+	if len(goalInputs) != 1 || goalInputs[0]["name"] != "Holiday" || goalInputs[0]["status"] == "" {
+		t.Errorf("savings_goals input = %v, want the Holiday goal with its status", goalInputs)
+	}
+}
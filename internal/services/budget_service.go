@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"gin-backend-app/internal/dto/response"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BudgetService struct {
+	BudgetRepo      repositories.UserBudgetRepository
+	TransactionRepo repositories.TransactionRepository
+}
+
+func NewBudgetService(budgetRepo repositories.UserBudgetRepository, transactionRepo repositories.TransactionRepository) *BudgetService {
+	return &BudgetService{BudgetRepo: budgetRepo, TransactionRepo: transactionRepo}
+}
+
+// GetBudgetStatus reports spending against every active budget for the
+// budget period containing at. Spending is counted from split lines, so only
+// the part of a split transaction booked to the budget category is included.
+func (s *BudgetService) GetBudgetStatus(ctx context.Context, userId uuid.UUID, at time.Time) ([]response.BudgetStatusResponse, error) {
+	budgets, err := s.BudgetRepo.ListActiveByUser(ctx, userId, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load budgets: %w", err)
+	}
+
+	result := make([]response.BudgetStatusResponse, 0, len(budgets))
+	for _, budget := range budgets {
+		status, err := s.budgetStatus(ctx, budget, at)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, status)
+	}
+	return result, nil
+}
+
+func (s *BudgetService) budgetStatus(ctx context.Context, budget *models.UserBudget, at time.Time) (response.BudgetStatusResponse, error) {
+	start, end, _, err := periodBounds(models.PeriodType(budget.PeriodType), at)
+	if err != nil {
+		return response.BudgetStatusResponse{}, err
+	}
+	if start.Before(budget.StartDate) {
+		start = budget.StartDate
+	}
+	if budget.EndDate != nil && end.After(*budget.EndDate) {
+		end = *budget.EndDate
+	}
+
+	spent, err := s.TransactionRepo.SumByCategoryBetween(ctx, budget.UserID, budget.CategoryID, models.TransactionGroupExpense, start, end)
+	if err != nil {
+		return response.BudgetStatusResponse{}, fmt.Errorf("failed to sum budget spending: %w", err)
+	}
+
+	var percent float64
+	if budget.Amount > 0 {
+		percent = spent / budget.Amount * 100
+	}
+
+	return response.BudgetStatusResponse{
+		BudgetID:     budget.ID,
+		CategoryID:   budget.CategoryID,
+		CategoryName: budget.Category.Name,
+		PeriodType:   budget.PeriodType,
+		PeriodStart:  start,
+		PeriodEnd:    end,
+		Amount:       budget.Amount,
+		Spent:        roundMoney(spent),
+		Remaining:    roundMoney(math.Max(budget.Amount-spent, 0)),
+		PercentUsed:  roundMoney(percent),
+		IsExceeded:   toCents(spent) > toCents(budget.Amount),
+	}, nil
+}
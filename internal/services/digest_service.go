@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"gin-backend-app/internal/dto/response"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"gin-backend-app/pkg/mailtemplate"
+	"gin-backend-app/pkg/utils"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	digestBatchSize     = 100
+	digestTopCategories = 5
+)
+
+type DigestService struct {
+	Tx            repositories.TxManager
+	UserRepo      repositories.UserRepository
+	DeliveryRepo  repositories.DigestDeliveryRepository
+	ReportService *ReportService
+	BudgetService *BudgetService
+	Outbox        *EmailOutboxService
+}
+
+func NewDigestService(tx repositories.TxManager, userRepo repositories.UserRepository, deliveryRepo repositories.DigestDeliveryRepository, reportService *ReportService, budgetService *BudgetService, outbox *EmailOutboxService) *DigestService {
+	return &DigestService{
+		Tx:            tx,
+		UserRepo:      userRepo,
+		DeliveryRepo:  deliveryRepo,
+		ReportService: reportService,
+		BudgetService: budgetService,
+		Outbox:        outbox,
+	}
+}
+
+// SendDigests queues the digest of the weekly or monthly period containing
+// at for every subscribed user. Users are processed in batches; a failure
+// for one user is logged and does not stop the run. Delivery pacing is left
+// to the outbox worker.
+func (s *DigestService) SendDigests(ctx context.Context, periodType models.PeriodType, at time.Time) (sent, failed int, err error) {
+	afterID := uuid.Nil
+	for {
+		users, err := s.UserRepo.ListDigestSubscribers(ctx, periodType, afterID, digestBatchSize)
+		if err != nil {
+			return sent, failed, fmt.Errorf("failed to list digest subscribers: %w", err)
+		}
+		if len(users) == 0 {
+			return sent, failed, nil
+		}
+
+		for _, user := range users {
+			// Deliveries are recorded per user, so a cancelled run can be
+			// repeated without sending duplicates.
+			if err := ctx.Err(); err != nil {
+				return sent, failed, err
+			}
+			queued, err := s.sendDigest(ctx, user, periodType, at)
+			if err != nil {
+				slog.ErrorContext(ctx, "digest failed", "user_id", user.ID, "period", periodType, "error", err)
+				failed++
+				continue
+			}
+			if queued {
+				sent++
+			}
+		}
+		afterID = users[len(users)-1].ID
+	}
+}
+
+func (s *DigestService) sendDigest(ctx context.Context, user *models.User, periodType models.PeriodType, at time.Time) (bool, error) {
+	start, _, _, err := periodBounds(periodType, at)
+	if err != nil {
+		return false, err
+	}
+	exists, err := s.DeliveryRepo.Exists(ctx, user.ID, periodType, start)
+	if err != nil || exists {
+		return false, err
+	}
+
+	report, err := s.ReportService.GenerateReport(ctx, user.ID, periodType, at)
+	if err != nil {
+		return false, err
+	}
+	previous, err := s.ReportService.GenerateReport(ctx, user.ID, periodType, start.AddDate(0, 0, -1))
+	if err != nil {
+		return false, err
+	}
+	budgets, err := s.BudgetService.GetBudgetStatus(ctx, user.ID, report.PeriodEnd)
+	if err != nil {
+		return false, err
+	}
+
+	email, err := mailtemplate.Render("digest", user.Locale, digestData(user, periodType, report, previous, budgets))
+	if err != nil {
+		return false, fmt.Errorf("failed to render digest: %w", err)
+	}
+
+	queued := false
+	err = s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		created, err := s.DeliveryRepo.CreateIfAbsent(ctx, &models.DigestDelivery{
+			UserID:      user.ID,
+			PeriodType:  periodType,
+			PeriodStart: start,
+		})
+		if err != nil || !created {
+			return err
+		}
+		queued = true
+		return s.Outbox.Enqueue(ctx, utils.Message{
+			To:      user.Email,
+			Subject: email.Subject,
+			HTML:    email.HTML,
+			Text:    email.Text,
+		})
+	})
+	return queued, err
+}
+
+func digestData(user *models.User, periodType models.PeriodType, report, previous *response.PeriodReportResponse, budgets []response.BudgetStatusResponse) map[string]any {
+	categories := make([]map[string]any, 0, digestTopCategories)
+	for _, category := range report.Categories {
+		if category.Type != string(models.TransactionGroupExpense) {
+			continue
+		}
+		share := 0.0
+		if report.TotalExpense > 0 {
+			share = category.Total / report.TotalExpense * 100
+		}
+		categories = append(categories, map[string]any{
+			"Name":  category.CategoryName,
+			"Total": formatMoney(category.Total),
+			"Share": fmt.Sprintf("%.0f", share),
+		})
+		if len(categories) == digestTopCategories {
+			break
+		}
+	}
+
+	budgetRows := make([]map[string]any, 0, len(budgets))
+	for _, budget := range budgets {
+		budgetRows = append(budgetRows, map[string]any{
+			"Category": budget.CategoryName,
+			"Spent":    formatMoney(budget.Spent),
+			"Amount":   formatMoney(budget.Amount),
+			"Percent":  fmt.Sprintf("%.0f", budget.PercentUsed),
+			"Exceeded": budget.IsExceeded,
+		})
+	}
+
+	return map[string]any{
+		"Name":          user.Name,
+		"PeriodType":    string(periodType),
+		"PeriodStart":   report.PeriodStart.Format(dateLayout),
+		"PeriodEnd":     report.PeriodEnd.Format(dateLayout),
+		"Income":        formatMoney(report.TotalIncome),
+		"Expense":       formatMoney(report.TotalExpense),
+		"NetFlow":       formatMoney(report.NetFlow),
+		"Comparison":    expenseComparison(user.Locale, report.TotalExpense, previous.TotalExpense),
+		"TopCategories": categories,
+		"Budgets":       budgetRows,
+	}
+}
+
+// expenseComparison describes the change in spending against the previous
+// period in the user's locale.
+func expenseComparison(locale string, current, previous float64) string {
+	if previous == 0 {
+		return mailtemplate.Translate(locale, "digest.comparison_none")
+	}
+
+	change := (current - previous) / previous * 100
+	switch {
+	case math.Abs(change) < 0.5:
+		return mailtemplate.Translate(locale, "digest.comparison_same")
+	case change > 0:
+		return mailtemplate.Translate(locale, "digest.comparison_up", fmt.Sprintf("%.0f", change))
+	default:
+		return mailtemplate.Translate(locale, "digest.comparison_down", fmt.Sprintf("%.0f", -change))
+	}
+}
+
+func formatMoney(amount float64) string {
+	return fmt.Sprintf("%.2f", amount)
+}
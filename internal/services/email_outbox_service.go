@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"gin-backend-app/pkg/utils"
+	"log/slog"
+	"time"
+)
+
+type EmailOutboxConfig struct {
+	// BatchSize is how many due messages one ProcessDue run claims.
+	BatchSize int
+	// MaxAttempts is the number of delivery attempts before a message is
+	// dead-lettered.
+	MaxAttempts int
+	// BaseBackoff is the delay after the first failure; it doubles with
+	// every further failure up to MaxBackoff.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Lease is how long a claimed message is reserved for one sender. It is
+	// renewed before each send and also bounds the send, so it only has to
+	// cover a single message, not the whole batch.
+	Lease time.Duration
+	// SendInterval is the minimum pause between two sends, so bulk mail
+	// such as digests does not burst against the mail server.
+	SendInterval time.Duration
+}
+
+func DefaultEmailOutboxConfig() EmailOutboxConfig {
+	return EmailOutboxConfig{
+		BatchSize:    20,
+		MaxAttempts:  8,
+		BaseBackoff:  30 * time.Second,
+		MaxBackoff:   time.Hour,
+		Lease:        2 * time.Minute,
+		SendInterval: 200 * time.Millisecond,
+	}
+}
+
+type EmailOutboxService struct {
+	OutboxRepo repositories.EmailOutboxRepository
+	Mailer     utils.Mailer
+	Config     EmailOutboxConfig
+}
+
+func NewEmailOutboxService(outboxRepo repositories.EmailOutboxRepository, mailer utils.Mailer, cfg EmailOutboxConfig) *EmailOutboxService {
+	return &EmailOutboxService{OutboxRepo: outboxRepo, Mailer: mailer, Config: cfg}
+}
+
+// Enqueue queues an email. Called inside a caller's transaction, it is
+// committed or rolled back together with the caller's writes.
+func (s *EmailOutboxService) Enqueue(ctx context.Context, msg utils.Message) error {
+	return s.OutboxRepo.Create(ctx, &models.EmailOutbox{
+		ToAddress: msg.To,
+		Subject:   msg.Subject,
+		Body:      msg.HTML,
+		TextBody:  msg.Text,
+	})
+}
+
+// ProcessDue delivers one batch of due messages. Before each send the
+// message's lease is renewed; a message whose lease expired and was claimed
+// by another replica meanwhile is skipped. When ctx is cancelled it stops
+// before the next message; the unsent rest of the batch is picked up again
+// once its lease expires.
+func (s *EmailOutboxService) ProcessDue(ctx context.Context) (sent, failed int, err error) {
+	now := time.Now()
+	messages, err := s.OutboxRepo.ClaimDue(ctx, now, s.Config.BatchSize, s.Config.Lease)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+
+	// The outcome of a send is recorded even after ctx is cancelled, so a
+	// delivered message is not sent a second time.
+	record := context.WithoutCancel(ctx)
+
+	for i, message := range messages {
+		if i > 0 && s.Config.SendInterval > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(s.Config.SendInterval):
+			}
+		}
+		if ctx.Err() != nil {
+			return sent, failed, ctx.Err()
+		}
+
+		renewed, err := s.OutboxRepo.RenewLease(ctx, message, time.Now().Add(s.Config.Lease))
+		if err != nil {
+			return sent, failed, fmt.Errorf("failed to renew outbox lease: %w", err)
+		}
+		if !renewed {
+			slog.WarnContext(ctx, "outbox message reclaimed by another sender", "message_id", message.ID)
+			continue
+		}
+		attempts := message.Attempts + 1
+
+		// A send may not outlive the lease, or another replica could claim
+		// the message and deliver it again.
+		sendCtx, cancel := context.WithDeadline(ctx, *message.LockedUntil)
+		sendErr := s.Mailer.Send(sendCtx, utils.Message{
+			To:      message.ToAddress,
+			Subject: message.Subject,
+			HTML:    message.Body,
+			Text:    message.TextBody,
+		})
+		cancel()
+		if sendErr == nil {
+			if err := s.OutboxRepo.MarkSent(record, message.ID, attempts); err != nil {
+				slog.ErrorContext(ctx, "failed to mark outbox message as sent", "message_id", message.ID, "error", err)
+			}
+			sent++
+			continue
+		}
+
+		failed++
+		dead := attempts >= s.Config.MaxAttempts
+		nextAttemptAt := time.Now().Add(s.backoff(attempts))
+		if dead {
+			slog.ErrorContext(ctx, "outbox message dead-lettered", "message_id", message.ID, "attempts", attempts, "error", sendErr)
+		} else {
+			slog.WarnContext(ctx, "outbox message failed", "message_id", message.ID, "attempts", attempts, "next_attempt_at", nextAttemptAt, "error", sendErr)
+		}
+
+		if err := s.OutboxRepo.MarkFailed(record, message.ID, attempts, nextAttemptAt, sendErr.Error(), dead); err != nil {
+			slog.ErrorContext(ctx, "failed to record outbox failure", "message_id", message.ID, "error", err)
+		}
+	}
+
+	return sent, failed, nil
+}
+
+// CleanupSent deletes delivered messages older than retention.
+func (s *EmailOutboxService) CleanupSent(ctx context.Context, retention time.Duration) error {
+	deleted, err := s.OutboxRepo.DeleteSentBefore(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "sent outbox messages cleaned up", "deleted", deleted)
+	return nil
+}
+
+func (s *EmailOutboxService) backoff(attempts int) time.Duration {
+	delay := s.Config.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= s.Config.MaxBackoff {
+			return s.Config.MaxBackoff
+		}
+	}
+	return delay
+}
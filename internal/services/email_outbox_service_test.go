@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories/fakes"
+	"gin-backend-app/pkg/utils"
+)
+
+// mailerFunc adapts a function to utils.Mailer.
+type mailerFunc func(ctx context.Context, msg utils.Message) error
+
+func (f mailerFunc) Send(ctx context.Context, msg utils.Message) error { return f(ctx, msg) }
+
+func newOutboxTest(t *testing.T, recipients ...string) *fakes.EmailOutboxRepository {
+	t.Helper()
+
+	repo := fakes.NewEmailOutboxRepository()
+	for i, to := range recipients {
+		// Distinct due times keep the claim order stable.
+		message := &models.EmailOutbox{ToAddress: to, Subject: "hello", NextAttemptAt: time.Now().Add(time.Duration(i-len(recipients)) * time.Second)}
+		if err := repo.Create(context.Background(), message); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return repo
+}
+
+func TestProcessDueSkipsMessagesReclaimedMidBatch(t *testing.T) {
+	ctx := context.Background()
+	repo := newOutboxTest(t, "a@example.com", "b@example.com")
+
+	var sent []string
+	mailer := mailerFunc(func(ctx context.Context, msg utils.Message) error {
+		if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > time.Minute {
+			t.Errorf("send deadline = %v, %v; want the lease", deadline, ok)
+		}
+		sent = append(sent, msg.To)
+		if len(sent) == 1 {
+			// The batch outlives its lease while this send runs, and
+			// another replica claims it.
+			if claimed, _ := repo.ClaimDue(ctx, time.Now().Add(time.Hour), 10, time.Minute); len(claimed) != 2 {
+				t.Fatalf("reclaimed %d messages, want 2", len(claimed))
+			}
+		}
+		return nil
+	})
+	cfg := DefaultEmailOutboxConfig()
+	cfg.Lease = time.Minute
+	cfg.SendInterval = 0
+	service := NewEmailOutboxService(repo, mailer, cfg)
+
+	gotSent, gotFailed, err := service.ProcessDue(ctx)
+	if err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+	if gotSent != 1 || gotFailed != 0 {
+		t.Errorf("sent, failed = %d, %d; want 1, 0", gotSent, gotFailed)
+	}
+	if len(sent) != 1 || sent[0] != "a@example.com" {
+		t.Errorf("delivered to %v, want only a@example.com", sent)
+	}
+
+	for _, message := range repo.Messages() {
+		want := models.EmailOutboxSent
+		if message.ToAddress == "b@example.com" {
+			// Still leased to the other replica.
+			want = models.EmailOutboxSending
+		}
+		if message.Status != want {
+			t.Errorf("%s status = %s, want %s", message.ToAddress, message.Status, want)
+		}
+	}
+}
+
+func TestProcessDueRenewsLeaseBeforeEachSend(t *testing.T) {
+	repo := newOutboxTest(t, "a@example.com", "b@example.com")
+	cfg := DefaultEmailOutboxConfig()
+	cfg.Lease = time.Minute
+	cfg.SendInterval = 10 * time.Millisecond
+
+	var claimedUntil time.Time
+	mailer := mailerFunc(func(ctx context.Context, msg utils.Message) error {
+		for _, message := range repo.Messages() {
+			if message.ToAddress != msg.To {
+				continue
+			}
+			if msg.To == "a@example.com" {
+				claimedUntil = *message.LockedUntil
+			} else if !message.LockedUntil.After(claimedUntil) {
+				t.Errorf("lease of %s = %v, want renewed past %v", msg.To, message.LockedUntil, claimedUntil)
+			}
+		}
+		return nil
+	})
+
+	if sent, _, err := NewEmailOutboxService(repo, mailer, cfg).ProcessDue(context.Background()); err != nil || sent != 2 {
+		t.Fatalf("ProcessDue = %d, %v; want 2 sent", sent, err)
+	}
+}
+
+func TestProcessDueRetriesWithBackoff(t *testing.T) {
+	ctx := context.Background()
+	repo := newOutboxTest(t, "a@example.com")
+	cfg := DefaultEmailOutboxConfig()
+	cfg.MaxAttempts = 3
+	cfg.BaseBackoff = time.Minute
+	cfg.MaxBackoff = 90 * time.Second
+
+	failing := true
+	mailer := mailerFunc(func(ctx context.Context, msg utils.Message) error {
+		if failing {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	service := NewEmailOutboxService(repo, mailer, cfg)
+
+	// Each failure reschedules the message; makeDue skips the wait.
+	for attempt, wantDelay := range []time.Duration{time.Minute, 90 * time.Second} {
+		before := time.Now()
+		if sent, failed, err := service.ProcessDue(ctx); err != nil || sent != 0 || failed != 1 {
+			t.Fatalf("attempt %d: ProcessDue = %d, %d, %v; want 0 sent, 1 failed", attempt+1, sent, failed, err)
+		}
+		message := repo.Messages()[0]
+		if message.Status != models.EmailOutboxPending || message.Attempts != attempt+1 {
+			t.Fatalf("attempt %d: status, attempts = %s, %d", attempt+1, message.Status, message.Attempts)
+		}
+		if message.LastError == nil || *message.LastError != "connection refused" {
+			t.Errorf("attempt %d: last error = %v", attempt+1, message.LastError)
+		}
+		if delay := message.NextAttemptAt.Sub(before); delay < wantDelay || delay > wantDelay+time.Second {
+			t.Errorf("attempt %d: retried after %s, want %s", attempt+1, delay, wantDelay)
+		}
+
+		// Not due yet.
+		if sent, failed, _ := service.ProcessDue(ctx); sent+failed != 0 {
+			t.Fatalf("attempt %d: message retried before its backoff", attempt+1)
+		}
+		makeDue(t, repo)
+	}
+
+	failing = false
+	if sent, failed, err := service.ProcessDue(ctx); err != nil || sent != 1 || failed != 0 {
+		t.Fatalf("ProcessDue = %d, %d, %v; want 1 sent", sent, failed, err)
+	}
+	message := repo.Messages()[0]
+	if message.Status != models.EmailOutboxSent || message.Attempts != 3 || message.SentAt == nil || message.LastError != nil {
+		t.Errorf("sent message = %+v", message)
+	}
+}
+
+func TestProcessDueDeadLettersAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	repo := newOutboxTest(t, "a@example.com")
+	cfg := DefaultEmailOutboxConfig()
+	cfg.MaxAttempts = 2
+
+	attempts := 0
+	mailer := mailerFunc(func(ctx context.Context, msg utils.Message) error {
+		attempts++
+		return errors.New("mailbox unavailable")
+	})
+	service := NewEmailOutboxService(repo, mailer, cfg)
+
+	for i := 0; i < cfg.MaxAttempts; i++ {
+		if _, failed, err := service.ProcessDue(ctx); err != nil || failed != 1 {
+			t.Fatalf("attempt %d: ProcessDue = %d failed, %v", i+1, failed, err)
+		}
+		makeDue(t, repo)
+	}
+	if message := repo.Messages()[0]; message.Status != models.EmailOutboxDead || message.Attempts != 2 {
+		t.Errorf("status, attempts = %s, %d; want dead, 2", message.Status, message.Attempts)
+	}
+
+	if sent, failed, _ := service.ProcessDue(ctx); sent+failed != 0 || attempts != 2 {
+		t.Errorf("dead-lettered message was retried (%d sends)", attempts)
+	}
+}
+
+func TestEmailOutboxBackoff(t *testing.T) {
+	service := NewEmailOutboxService(nil, nil, EmailOutboxConfig{BaseBackoff: 30 * time.Second, MaxBackoff: 5 * time.Minute})
+	for attempts, want := range map[int]time.Duration{
+		1: 30 * time.Second,
+		2: time.Minute,
+		3: 2 * time.Minute,
+		4: 4 * time.Minute,
+		5: 5 * time.Minute,
+		9: 5 * time.Minute,
+	} {
+		if got := service.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
+
+// makeDue moves the pending messages' next attempt into the past.
+func makeDue(t *testing.T, repo *fakes.EmailOutboxRepository) {
+	t.Helper()
+	for _, message := range repo.Messages() {
+		if message.Status != models.EmailOutboxPending {
+			continue
+		}
+		lastError := ""
+		if message.LastError != nil {
+			lastError = *message.LastError
+		}
+		if err := repo.MarkFailed(context.Background(), message.ID, message.Attempts, time.Now().Add(-time.Second), lastError, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
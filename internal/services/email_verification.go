@@ -1,131 +1,164 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"gin-backend-app/internal/dto/request"
+	"gin-backend-app/internal/apperror"
+	"gin-backend-app/internal/metrics"
 	"gin-backend-app/internal/models"
 	"gin-backend-app/internal/repositories"
+	"gin-backend-app/pkg/mailtemplate"
 	"gin-backend-app/pkg/utils"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
+var (
+	ErrOTPInvalid           = apperror.New(apperror.CodeOTPInvalid, "invalid otp")
+	ErrOTPExpired           = apperror.New(apperror.CodeOTPExpired, "otp is already expired")
+	ErrOTPAlreadyUsed       = apperror.New(apperror.CodeOTPAlreadyUsed, "token is already used")
+	ErrEmailAlreadyVerified = apperror.New(apperror.CodeEmailAlreadyVerified, "email is already verified")
+	ErrResendTooSoon        = apperror.New(apperror.CodeRateLimited, "you already request new verification link, try again in one minute")
+)
+
+// otpTokenTTL is how long verification and password reset codes stay valid.
+const otpTokenTTL = 24 * time.Hour
+
 type EmailVerficationService struct {
-	UserRepo *repositories.UserRepository
-	UserTokenEmail *repositories.UserTokenRepository
-	Mailer *utils.SMTPMailer
+	Tx repositories.TxManager
+	UserRepo repositories.UserRepository
+	UserTokenEmail repositories.UserTokenRepository
+	Outbox *EmailOutboxService
 	BaseUrl string
 }
 
-func NewEmailVerificationService(userRepo *repositories.UserRepository, userTokenEmailRepo *repositories.UserTokenRepository, mailer *utils.SMTPMailer, baseUrl string ) *EmailVerficationService {
-	return &EmailVerficationService{UserRepo: userRepo, UserTokenEmail: userTokenEmailRepo, Mailer: mailer, BaseUrl: baseUrl }
+func NewEmailVerificationService(tx repositories.TxManager, userRepo repositories.UserRepository, userTokenEmailRepo repositories.UserTokenRepository, outbox *EmailOutboxService, baseUrl string ) *EmailVerficationService {
+	return &EmailVerficationService{Tx: tx, UserRepo: userRepo, UserTokenEmail: userTokenEmailRepo, Outbox: outbox, BaseUrl: baseUrl }
 }
 
-func (s *EmailVerficationService) GetUserByID (userId uuid.UUID) (*models.User, error) {
-	return s.UserRepo.FindByID(userId)
+func (s *EmailVerficationService) GetUserByID (ctx context.Context, userId uuid.UUID) (*models.User, error) {
+	user, err := s.UserRepo.FindByID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
 }
 
-func (s *EmailVerficationService) VerifiyEmail (otp string, userId uuid.UUID) error {
+// VerifiyEmail marks the user verified and consumes the OTP in one
+// transaction, so a code can never verify without being used up.
+func (s *EmailVerficationService) VerifiyEmail (ctx context.Context, otp string, userId uuid.UUID) error {
+	err := s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.verifyEmail(ctx, otp, userId)
+	})
+	if err != nil {
+		return err
+	}
+	metrics.EmailVerified()
+	return nil
+}
 
-	token, err := s.UserTokenEmail.FindTokenByOTP(otp, models.TokenTypeEmailVerification, userId)
+func (s *EmailVerficationService) verifyEmail(ctx context.Context, otp string, userId uuid.UUID) error {
+	token, err := s.UserTokenEmail.FindTokenByOTP(ctx, otp, models.TokenTypeEmailVerification, userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrOTPInvalid
+	}
 	if err != nil {
-		return errors.New("invalid otp")
+		return err
 	}
 
 	if time.Now().After(token.ExpiresAt) {
-		return errors.New("otp is already expired")
+		return ErrOTPExpired
 	}
 
 	if token.UsedAt != nil {
-		return errors.New("token is already used")
+		return ErrOTPAlreadyUsed
 	}
 
-	if err := s.UserRepo.MarkEmailVerified(token.UserID); err != nil {
+	if err := s.UserRepo.MarkEmailVerified(ctx, token.UserID); err != nil {
 		return err
 	} 
-	return s.UserTokenEmail.MarkAsUsedToken(token.ID)
+	return s.UserTokenEmail.MarkAsUsedToken(ctx, token.ID)
+}
+
+// SendEmailVerification stores the OTP token and queues its email in the
+// outbox in one transaction, so the code is never stored without its email
+// or the other way round.
+func (s *EmailVerficationService) SendEmailVerification(ctx context.Context, user *models.User, emailVerificationType models.TokenType) error {
+	return s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.sendEmailVerification(ctx, user, emailVerificationType)
+	})
 }
 
-func (s *EmailVerficationService) SendEmailVerification(user *models.User, emailVerificationType models.TokenType) error {
+func (s *EmailVerficationService) sendEmailVerification(ctx context.Context, user *models.User, emailVerificationType models.TokenType) error {
 	otpToken, err := utils.GenerateUppercaseSixDigitOTP()
 	if err != nil {
 		return fmt.Errorf("failed to generate otp: %w", err)
 	}
 
-	if err := s.UserTokenEmail.Create(&models.UserToken{
+	if err := s.UserTokenEmail.Create(ctx, &models.UserToken{
 		UserID:    user.ID,
 		TokenOtp:  otpToken,
 		TokenType: emailVerificationType,
-		ExpiresAt: time.Now().Add(24 * time.Hour),
+		ExpiresAt: time.Now().Add(otpTokenTTL),
 	}); err != nil {
 		return fmt.Errorf("failed to store user token: %w", err)
 	}
 
-	emailData := &request.EmailData{
-		OTPCode: otpToken,
-	}
-
-	var (
-		subject string
-		html    string
-	)
-
+	var templateName string
 	switch emailVerificationType {
 	case models.TokenTypeEmailVerification:
-		emailData.Title = "Verify Your Email Address"
-		emailData.Message = fmt.Sprintf(
-			"Hi %s, please verify your email to activate your account.",
-			user.Name,
-		)
-
-		subject = "Email Verification"
-		html, err = utils.BuildVerificationEmailHTML(emailData)
-
+		templateName = "verification"
 	case models.TokenTypePasswordReset:
-		emailData.Title = "Reset Your Password"
-		emailData.Message = fmt.Sprintf(
-			"Hi %s, use the following code to reset your password.",
-			user.Name,
-		)
-
-		subject = "Password Reset Request"
-		html, err = utils.BuildResetPasswordEmailHTML(emailData)
-
+		templateName = "password_reset"
 	default:
 		return fmt.Errorf("unsupported token type: %s", emailVerificationType)
 	}
 
+	email, err := mailtemplate.Render(templateName, user.Locale, map[string]any{
+		"Name":           user.Name,
+		"OTPCode":        otpToken,
+		"ExpiresInHours": int(otpTokenTTL.Hours()),
+	})
 	if err != nil {
-		return fmt.Errorf("failed to build email html: %w", err)
+		return fmt.Errorf("failed to render email: %w", err)
 	}
 
-	if err := s.Mailer.Send(user.Email, subject, html); err != nil {
-		return fmt.Errorf("failed to send email: %w", err)
+	if err := s.Outbox.Enqueue(ctx, utils.Message{
+		To:      user.Email,
+		Subject: email.Subject,
+		HTML:    email.HTML,
+		Text:    email.Text,
+	}); err != nil {
+		return fmt.Errorf("failed to queue email: %w", err)
 	}
 
 	return nil
 }
 
-func (s *EmailVerficationService) ResendEmailverification (user *models.User , emailVerificationType models.TokenType) error {
+func (s *EmailVerficationService) ResendEmailverification (ctx context.Context, user *models.User , emailVerificationType models.TokenType) error {
 	if user.IsEmailVerified {
-		return errors.New("email is already verified")
+		return ErrEmailAlreadyVerified
 	}
-	canResend := s.UserTokenEmail.CheckUserTokenCreatedAt(user.ID)
+	canResend := s.UserTokenEmail.CheckUserTokenCreatedAt(ctx, user.ID)
 	if !canResend {
-		return errors.New("you already request new verification link, try again in one minute")
+		return ErrResendTooSoon
 	}
-	return s.SendEmailVerification(user, models.TokenTypeEmailVerification)
+	return s.SendEmailVerification(ctx, user, models.TokenTypeEmailVerification)
 }
 
-func (s *EmailVerficationService) CleanupExpiredTokens() error {
+func (s *EmailVerficationService) CleanupExpiredTokens(ctx context.Context) error {
 	now := time.Now()
-	deleted, err := s.UserTokenEmail.DeleteExpired(now)
+	deleted, err := s.UserTokenEmail.DeleteExpired(ctx, now)
 	if err != nil {
 		return err
 	}
-	log.Printf("[SERVICE] CleanupExpiredTokens: %d tokens deleted\n", deleted)
+	slog.InfoContext(ctx, "expired tokens cleaned up", "deleted", deleted)
 	return nil
 }
\ No newline at end of file
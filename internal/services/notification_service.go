@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"gin-backend-app/internal/apperror"
+	"gin-backend-app/internal/dto/request"
+	"gin-backend-app/internal/dto/response"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"gin-backend-app/pkg/mailtemplate"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const defaultNotificationPageSize = 20
+
+var ErrNotificationNotFound = apperror.New(apperror.CodeNotificationNotFound, "notification not found")
+
+// NotificationEvent is the realtime event type carrying a new notification.
+const NotificationEvent = "notification"
+
+type NotificationService struct {
+	Tx               repositories.TxManager
+	NotificationRepo repositories.NotificationRepository
+	UserRepo         repositories.UserRepository
+}
+
+func NewNotificationService(tx repositories.TxManager, notificationRepo repositories.NotificationRepository, userRepo repositories.UserRepository) *NotificationService {
+	return &NotificationService{Tx: tx, NotificationRepo: notificationRepo, UserRepo: userRepo}
+}
+
+// Create stores a notification and publishes it to the user's live streams.
+// Called inside a caller's transaction, both are committed together with the
+// event the notification reports.
+func (s *NotificationService) Create(ctx context.Context, notification *models.Notification) error {
+	return s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.NotificationRepo.Create(ctx, notification); err != nil {
+			return err
+		}
+		return s.NotificationRepo.Publish(ctx, notification.UserID, NotificationEvent, toNotificationResponse(notification))
+	})
+}
+
+// Notify stores a notification whose title and body are the catalog entries
+// "notification.<type>.title" and "notification.<type>.body" in the user's
+// locale, formatted with titleArgs and bodyArgs.
+func (s *NotificationService) Notify(ctx context.Context, userId uuid.UUID, notificationType models.NotificationType, data map[string]any, titleArgs, bodyArgs []any) error {
+	user, err := s.UserRepo.FindByID(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return nil
+	}
+
+	prefix := "notification." + string(notificationType)
+	return s.Create(ctx, &models.Notification{
+		UserID: userId,
+		Type:   notificationType,
+		Title:  mailtemplate.Translate(user.Locale, prefix+".title", titleArgs...),
+		Body:   mailtemplate.Translate(user.Locale, prefix+".body", bodyArgs...),
+		Data:   data,
+	})
+}
+
+func (s *NotificationService) UnreadCount(ctx context.Context, userId uuid.UUID) (int64, error) {
+	return s.NotificationRepo.CountUnread(ctx, userId)
+}
+
+func (s *NotificationService) ListNotifications(ctx context.Context, userId uuid.UUID, query request.ListNotificationsQuery) (*response.NotificationListResponse, error) {
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultNotificationPageSize
+	}
+	page := query.Page
+	if page == 0 {
+		page = 1
+	}
+
+	notifications, total, err := s.NotificationRepo.List(ctx, userId, query.Unread, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := s.NotificationRepo.CountUnread(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.NotificationResponse, 0, len(notifications))
+	for _, notification := range notifications {
+		items = append(items, toNotificationResponse(notification))
+	}
+
+	return &response.NotificationListResponse{
+		Items:  items,
+		Total:  total,
+		Unread: unread,
+		Page:   page,
+		Limit:  limit,
+	}, nil
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, userId, notificationId uuid.UUID) error {
+	err := s.NotificationRepo.MarkRead(ctx, notificationId, userId, time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotificationNotFound
+	}
+	return err
+}
+
+func (s *NotificationService) MarkAllRead(ctx context.Context, userId uuid.UUID) (*response.NotificationReadAllResponse, error) {
+	updated, err := s.NotificationRepo.MarkAllRead(ctx, userId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &response.NotificationReadAllResponse{Updated: updated}, nil
+}
+
+func toNotificationResponse(notification *models.Notification) response.NotificationResponse {
+	return response.NotificationResponse{
+		ID:        notification.ID,
+		Type:      string(notification.Type),
+		Title:     notification.Title,
+		Body:      notification.Body,
+		Data:      notification.Data,
+		IsRead:    notification.ReadAt != nil,
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
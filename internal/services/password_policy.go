@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"gin-backend-app/internal/apperror"
+	"log/slog"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// minPersonalLen is the shortest name or email part a password is checked
+// against; shorter ones would reject too many passwords by accident.
+const minPersonalLen = 3
+
+// BreachedPasswords reports whether a password is known from a data breach.
+type BreachedPasswords interface {
+	Contains(password string) (bool, error)
+}
+
+// PasswordPolicy is checked whenever a user chooses a password.
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters.
+	MinLength int
+	// MinCharClasses is how many of lower case letters, upper case letters,
+	// digits and symbols the password must mix.
+	MinCharClasses int
+	// Breached rejects passwords found in a breach when set.
+	Breached BreachedPasswords
+}
+
+// Check validates password against the policy. personal holds the user's
+// name and email, which the password must not contain. Violations are
+// reported on field as PASSWORD_TOO_WEAK or PASSWORD_BREACHED.
+func (p PasswordPolicy) Check(ctx context.Context, field, password string, personal ...string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return passwordError(apperror.CodePasswordTooWeak, field, "min",
+			fmt.Sprintf("password must be at least %d characters", p.MinLength))
+	}
+
+	if charClasses(password) < p.MinCharClasses {
+		return passwordError(apperror.CodePasswordTooWeak, field, "char_classes",
+			fmt.Sprintf("password must mix at least %d of lower case letters, upper case letters, digits and symbols", p.MinCharClasses))
+	}
+
+	lower := strings.ToLower(password)
+	for _, value := range personal {
+		if local, _, ok := strings.Cut(value, "@"); ok {
+			value = local
+		}
+		value = strings.ToLower(strings.TrimSpace(value))
+		if utf8.RuneCountInString(value) >= minPersonalLen && strings.Contains(lower, value) {
+			return passwordError(apperror.CodePasswordTooWeak, field, "personal",
+				"password must not contain your name or email")
+		}
+	}
+
+	if p.Breached != nil {
+		breached, err := p.Breached.Contains(password)
+		if err != nil {
+			// A broken dataset must not block sign ups and resets.
+			slog.WarnContext(ctx, "breached password lookup failed", "error", err)
+		} else if breached {
+			return passwordError(apperror.CodePasswordBreached, field, "breached",
+				"this password has appeared in a data breach, choose another one")
+		}
+	}
+	return nil
+}
+
+func charClasses(password string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	count := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			count++
+		}
+	}
+	return count
+}
+
+func passwordError(code apperror.Code, field, rule, message string) error {
+	err := apperror.New(code, message)
+	err.Details = []apperror.FieldError{{Field: field, Rule: rule, Message: message}}
+	return err
+}
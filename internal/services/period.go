@@ -0,0 +1,30 @@
+package services
+
+import (
+	"fmt"
+	"gin-backend-app/internal/models"
+	"time"
+)
+
+// periodBounds returns the first and last day of the calendar week (Monday
+// based) or month containing t, together with the ISO week or month number
+// stored as PeriodValue.
+func periodBounds(periodType models.PeriodType, t time.Time) (start, end time.Time, value int, err error) {
+	day := truncateToDate(t)
+
+	switch periodType {
+	case models.PeriodWeekly:
+		offset := (int(day.Weekday()) + 6) % 7
+		start = day.AddDate(0, 0, -offset)
+		end = start.AddDate(0, 0, 6)
+		_, value = start.ISOWeek()
+	case models.PeriodMonthly:
+		start = time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(0, 1, -1)
+		value = int(start.Month())
+	default:
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("unsupported period type: %s", periodType)
+	}
+
+	return start, end, value, nil
+}
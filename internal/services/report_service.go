@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"gin-backend-app/internal/dto/response"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReportService struct {
+	TransactionRepo repositories.TransactionRepository
+	ReportRepo      repositories.PeriodReportRepository
+}
+
+func NewReportService(transactionRepo repositories.TransactionRepository, reportRepo repositories.PeriodReportRepository) *ReportService {
+	return &ReportService{TransactionRepo: transactionRepo, ReportRepo: reportRepo}
+}
+
+// GenerateReport computes the weekly or monthly report for the period
+// containing at and stores it, replacing a previously generated version.
+// Category totals are aggregated from split lines.
+func (s *ReportService) GenerateReport(ctx context.Context, userId uuid.UUID, periodType models.PeriodType, at time.Time) (*response.PeriodReportResponse, error) {
+	start, end, value, err := periodBounds(periodType, at)
+	if err != nil {
+		return nil, err
+	}
+
+	income, expense, err := s.TransactionRepo.SumByType(ctx, userId, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum transactions: %w", err)
+	}
+
+	totals, err := s.TransactionRepo.SumByCategory(ctx, userId, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate categories: %w", err)
+	}
+
+	categories := make([]response.CategoryBreakdownResponse, 0, len(totals))
+	categoryData := make([]map[string]interface{}, 0, len(totals))
+	for _, total := range totals {
+		categories = append(categories, response.CategoryBreakdownResponse{
+			CategoryID:   total.CategoryID,
+			CategoryName: total.CategoryName,
+			Type:         string(total.Type),
+			Total:        roundMoney(total.Total),
+			Count:        total.Count,
+		})
+		categoryData = append(categoryData, map[string]interface{}{
+			"category_id":   total.CategoryID.String(),
+			"category_name": total.CategoryName,
+			"type":          total.Type,
+			"total":         roundMoney(total.Total),
+			"count":         total.Count,
+		})
+	}
+
+	now := time.Now()
+	report := &models.PeriodReport{
+		UserID:       userId,
+		PeriodType:   periodType,
+		PeriodValue:  value,
+		PeriodStart:  start,
+		PeriodEnd:    end,
+		TotalIncome:  roundMoney(income),
+		TotalExpense: roundMoney(expense),
+		NetFlow:      roundMoney(income - expense),
+		ReportData: map[string]interface{}{
+			"categories": categoryData,
+		},
+		GeneratedAt: now,
+		UpdatedAt:   now,
+	}
+
+	if err := s.ReportRepo.Save(ctx, report); err != nil {
+		return nil, fmt.Errorf("failed to save period report: %w", err)
+	}
+
+	return &response.PeriodReportResponse{
+		ID:           report.ID,
+		PeriodType:   string(report.PeriodType),
+		PeriodValue:  report.PeriodValue,
+		PeriodStart:  report.PeriodStart,
+		PeriodEnd:    report.PeriodEnd,
+		TotalIncome:  report.TotalIncome,
+		TotalExpense: report.TotalExpense,
+		NetFlow:      report.NetFlow,
+		Categories:   categories,
+		GeneratedAt:  report.GeneratedAt,
+	}, nil
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gin-backend-app/internal/apperror"
+	"gin-backend-app/internal/dto/request"
+	"gin-backend-app/internal/dto/response"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	dateLayout = "2006-01-02"
+
+	// goalTrailingMonths is the window used to estimate the contribution rate
+	// when projecting a goal's completion date.
+	goalTrailingMonths = 3
+	daysPerMonth       = 365.25 / 12
+)
+
+var ErrSavingsGoalNotFound = apperror.New(apperror.CodeSavingsGoalNotFound, "savings goal not found")
+
+type SavingsGoalService struct {
+	GoalRepo        repositories.SavingsGoalRepository
+	CategoryRepo    repositories.CategoryRepository
+	TransactionRepo repositories.TransactionRepository
+}
+
+func NewSavingsGoalService(goalRepo repositories.SavingsGoalRepository, categoryRepo repositories.CategoryRepository, transactionRepo repositories.TransactionRepository) *SavingsGoalService {
+	return &SavingsGoalService{GoalRepo: goalRepo, CategoryRepo: categoryRepo, TransactionRepo: transactionRepo}
+}
+
+func (s *SavingsGoalService) CreateGoal(ctx context.Context, userId uuid.UUID, req request.CreateSavingsGoalRequest) (*response.SavingsGoalResponse, error) {
+	startDate := truncateToDate(time.Now())
+	if req.StartDate != "" {
+		parsed, err := time.Parse(dateLayout, req.StartDate)
+		if err != nil {
+			return nil, apperror.Field("start_date", "datetime", "invalid start date")
+		}
+		startDate = parsed
+	}
+
+	goal := &models.SavingsGoal{
+		UserID:       userId,
+		Name:         req.Name,
+		TargetAmount: req.TargetAmount,
+		StartDate:    startDate,
+		Description:  req.Description,
+	}
+	if err := s.applyGoalFields(ctx, goal, req.CategoryID, req.Deadline); err != nil {
+		return nil, err
+	}
+
+	if err := s.GoalRepo.Create(ctx, goal); err != nil {
+		return nil, fmt.Errorf("failed to create savings goal: %w", err)
+	}
+
+	return s.GetGoal(ctx, userId, goal.ID)
+}
+
+func (s *SavingsGoalService) UpdateGoal(ctx context.Context, userId, goalId uuid.UUID, req request.UpdateSavingsGoalRequest) (*response.SavingsGoalResponse, error) {
+	goal, err := s.GoalRepo.FindByID(ctx, goalId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if goal == nil {
+		return nil, ErrSavingsGoalNotFound
+	}
+
+	startDate, err := time.Parse(dateLayout, req.StartDate)
+	if err != nil {
+		return nil, apperror.Field("start_date", "datetime", "invalid start date")
+	}
+
+	goal.Name = req.Name
+	goal.TargetAmount = req.TargetAmount
+	goal.StartDate = startDate
+	goal.Description = req.Description
+	if err := s.applyGoalFields(ctx, goal, req.CategoryID, req.Deadline); err != nil {
+		return nil, err
+	}
+
+	if err := s.GoalRepo.Update(ctx, goal); err != nil {
+		return nil, fmt.Errorf("failed to update savings goal: %w", err)
+	}
+
+	return s.GetGoal(ctx, userId, goal.ID)
+}
+
+func (s *SavingsGoalService) GetGoal(ctx context.Context, userId, goalId uuid.UUID) (*response.SavingsGoalResponse, error) {
+	goal, err := s.GoalRepo.FindByID(ctx, goalId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if goal == nil {
+		return nil, ErrSavingsGoalNotFound
+	}
+
+	return s.buildGoalResponse(ctx, goal, time.Now())
+}
+
+func (s *SavingsGoalService) ListGoals(ctx context.Context, userId uuid.UUID) ([]response.SavingsGoalResponse, error) {
+	goals, err := s.GoalRepo.ListByUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]response.SavingsGoalResponse, 0, len(goals))
+	for _, goal := range goals {
+		res, err := s.buildGoalResponse(ctx, goal, now)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *res)
+	}
+	return result, nil
+}
+
+func (s *SavingsGoalService) DeleteGoal(ctx context.Context, userId, goalId uuid.UUID) error {
+	if err := s.GoalRepo.Delete(ctx, goalId, userId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSavingsGoalNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *SavingsGoalService) applyGoalFields(ctx context.Context, goal *models.SavingsGoal, categoryId, deadline string) error {
+	parsedDeadline, err := time.Parse(dateLayout, deadline)
+	if err != nil {
+		return apperror.Field("deadline", "datetime", "invalid deadline")
+	}
+	if !parsedDeadline.After(goal.StartDate) {
+		return apperror.Field("deadline", "gtfield", "deadline must be after the start date")
+	}
+
+	parsedCategoryID, err := uuid.Parse(categoryId)
+	if err != nil {
+		return apperror.Field("category_id", "uuid", "invalid category id")
+	}
+	category, err := s.CategoryRepo.FindByID(ctx, parsedCategoryID, goal.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find category: %w", err)
+	}
+	if category == nil {
+		return apperror.Field("category_id", "exists", "category not found")
+	}
+
+	goal.Deadline = parsedDeadline
+	goal.CategoryID = &category.ID
+	goal.Category = category
+	return nil
+}
+
+func (s *SavingsGoalService) buildGoalResponse(ctx context.Context, goal *models.SavingsGoal, now time.Time) (*response.SavingsGoalResponse, error) {
+	progress, err := s.computeProgress(ctx, goal, now)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.SavingsGoalResponse{
+		ID:           goal.ID,
+		Name:         goal.Name,
+		TargetAmount: goal.TargetAmount,
+		CategoryID:   goal.CategoryID,
+		StartDate:    goal.StartDate,
+		Deadline:     goal.Deadline,
+		Description:  goal.Description,
+		Progress:     progress,
+		CreatedAt:    goal.CreatedAt,
+		UpdatedAt:    goal.UpdatedAt,
+	}
+	if goal.Category != nil {
+		res.CategoryName = &goal.Category.Name
+	}
+	return res, nil
+}
+
+// computeProgress derives the contributed amount from transactions booked to
+// the goal's linked category, the monthly contribution still required to meet
+// the deadline and a completion date projected from the trailing contribution
+// rate.
+func (s *SavingsGoalService) computeProgress(ctx context.Context, goal *models.SavingsGoal, now time.Time) (response.GoalProgressResponse, error) {
+	today := truncateToDate(now)
+
+	var contributed, trailing float64
+	windowStart := today.AddDate(0, -goalTrailingMonths, 0)
+	if windowStart.Before(goal.StartDate) {
+		windowStart = goal.StartDate
+	}
+
+	if goal.CategoryID != nil {
+		txType := goalContributionType(goal)
+		var err error
+		contributed, err = s.TransactionRepo.SumByCategoryBetween(ctx, goal.UserID, *goal.CategoryID, txType, goal.StartDate, today)
+		if err != nil {
+			return response.GoalProgressResponse{}, fmt.Errorf("failed to sum goal contributions: %w", err)
+		}
+		trailing, err = s.TransactionRepo.SumByCategoryBetween(ctx, goal.UserID, *goal.CategoryID, txType, windowStart, today)
+		if err != nil {
+			return response.GoalProgressResponse{}, fmt.Errorf("failed to sum trailing contributions: %w", err)
+		}
+	}
+
+	return goalProgress(goal.TargetAmount, goal.Deadline, contributed, trailing, windowStart, today), nil
+}
+
+// goalContributionType is the transaction type that counts towards a goal:
+// the group type of its linked category, so lines of the other type booked to
+// the same category never inflate progress. Money set aside is an expense
+// when the category is not loaded.
+func goalContributionType(goal *models.SavingsGoal) models.TransactionGroupType {
+	if goal.Category != nil {
+		return goal.Category.GroupType
+	}
+	return models.TransactionGroupExpense
+}
+
+func goalProgress(target float64, deadline time.Time, contributed, trailing float64, windowStart, today time.Time) response.GoalProgressResponse {
+	remaining := math.Max(target-contributed, 0)
+
+	progress := response.GoalProgressResponse{
+		ContributedAmount: roundMoney(contributed),
+		RemainingAmount:   roundMoney(remaining),
+		PercentComplete:   roundMoney(math.Min(contributed/target*100, 100)),
+	}
+
+	// Average over at least one month so a goal started yesterday does not
+	// extrapolate a single deposit into an unrealistic monthly rate.
+	rate := trailing / math.Max(monthsBetween(windowStart, today), 1)
+	progress.AverageMonthlyContribution = roundMoney(rate)
+
+	if remaining == 0 {
+		progress.Status = string(models.GoalStatusAchieved)
+		progress.ProjectedCompletionDate = &today
+		return progress
+	}
+
+	if today.After(deadline) {
+		progress.RequiredMonthlyContribution = roundMoney(remaining)
+	} else {
+		progress.RequiredMonthlyContribution = roundMoney(remaining / math.Max(monthsBetween(today, deadline), 1))
+	}
+
+	if rate > 0 {
+		days := math.Ceil(remaining / rate * daysPerMonth)
+		projected := today.AddDate(0, 0, int(days))
+		progress.ProjectedCompletionDate = &projected
+	}
+
+	switch {
+	case today.After(deadline):
+		progress.Status = string(models.GoalStatusOverdue)
+	case progress.ProjectedCompletionDate == nil:
+		progress.Status = string(models.GoalStatusNoActivity)
+	case progress.ProjectedCompletionDate.After(deadline):
+		progress.Status = string(models.GoalStatusBehind)
+	default:
+		progress.Status = string(models.GoalStatusOnTrack)
+	}
+
+	return progress
+}
+
+func monthsBetween(from, to time.Time) float64 {
+	return to.Sub(from).Hours() / 24 / daysPerMonth
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func roundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gin-backend-app/internal/apperror"
+	"gin-backend-app/internal/dto/request"
+	"gin-backend-app/internal/dto/response"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const maxTagsPerTransaction = 20
+
+var (
+	ErrTagNotFound = apperror.New(apperror.CodeTagNotFound, "tag not found")
+	ErrTagExists   = apperror.New(apperror.CodeTagAlreadyExists, "tag already exists")
+
+	tagNamePattern = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N} _.-]{0,49}$`)
+)
+
+type TagService struct {
+	TagRepo repositories.TagRepository
+}
+
+func NewTagService(tagRepo repositories.TagRepository) *TagService {
+	return &TagService{TagRepo: tagRepo}
+}
+
+func (s *TagService) CreateTag(ctx context.Context, userId uuid.UUID, req request.TagRequest) (*response.TagResponse, error) {
+	name, err := normalizeTagName("name", req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.TagRepo.FindByName(ctx, userId, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrTagExists
+	}
+
+	tag := &models.Tag{UserID: userId, Name: name, Color: req.Color}
+	if tag.Color == "" {
+		tag.Color = "#000000"
+	}
+	if err := s.TagRepo.Create(ctx, tag); err != nil {
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	res := toTagResponse(tag)
+	return &res, nil
+}
+
+func (s *TagService) UpdateTag(ctx context.Context, userId, tagId uuid.UUID, req request.TagRequest) (*response.TagResponse, error) {
+	tag, err := s.TagRepo.FindByID(ctx, tagId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if tag == nil {
+		return nil, ErrTagNotFound
+	}
+
+	name, err := normalizeTagName("name", req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != tag.Name {
+		existing, err := s.TagRepo.FindByName(ctx, userId, name)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, ErrTagExists
+		}
+	}
+
+	tag.Name = name
+	if req.Color != "" {
+		tag.Color = req.Color
+	}
+	tag.UpdatedAt = time.Now()
+	if err := s.TagRepo.Update(ctx, tag); err != nil {
+		return nil, fmt.Errorf("failed to update tag: %w", err)
+	}
+
+	res := toTagResponse(tag)
+	return &res, nil
+}
+
+func (s *TagService) ListTags(ctx context.Context, userId uuid.UUID) ([]response.TagResponse, error) {
+	tags, err := s.TagRepo.ListByUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.TagResponse, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, toTagResponse(tag))
+	}
+	return result, nil
+}
+
+// DeleteTag removes the tag; it is detached from its transactions by the
+// join table's cascading foreign key.
+func (s *TagService) DeleteTag(ctx context.Context, userId, tagId uuid.UUID) error {
+	if err := s.TagRepo.Delete(ctx, tagId, userId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTagNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *TagService) GetTagSpending(ctx context.Context, userId uuid.UUID, query request.TagSpendingQuery) ([]response.TagSpendingResponse, error) {
+	from, err := time.Parse(dateLayout, query.From)
+	if err != nil {
+		return nil, apperror.Field("from", "datetime", "invalid from date")
+	}
+	to, err := time.Parse(dateLayout, query.To)
+	if err != nil {
+		return nil, apperror.Field("to", "datetime", "invalid to date")
+	}
+	if to.Before(from) {
+		return nil, apperror.Field("to", "gtefield", "to date must not be before from date")
+	}
+
+	spending, err := s.TagRepo.SpendingByTag(ctx, userId, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate tag spending: %w", err)
+	}
+
+	result := make([]response.TagSpendingResponse, 0, len(spending))
+	for _, row := range spending {
+		result = append(result, response.TagSpendingResponse{
+			TagID:   row.TagID,
+			Name:    row.Name,
+			Income:  roundMoney(row.Income),
+			Expense: roundMoney(row.Expense),
+			Count:   row.Count,
+		})
+	}
+	return result, nil
+}
+
+func normalizeTagName(field, name string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if !tagNamePattern.MatchString(normalized) {
+		return "", apperror.Field(field, "tag", fmt.Sprintf("invalid tag %q, use up to 50 letters, digits, spaces, '.', '_' or '-'", name))
+	}
+	return normalized, nil
+}
+
+// normalizeTagNames normalizes and de-duplicates tag names, keeping the
+// order in which they were given.
+func normalizeTagNames(names []string) ([]string, error) {
+	if len(names) > maxTagsPerTransaction {
+		return nil, apperror.Field("tags", "max", fmt.Sprintf("a transaction can have at most %d tags", maxTagsPerTransaction))
+	}
+
+	seen := make(map[string]struct{}, len(names))
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		normalized, err := normalizeTagName("tags", name)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[normalized]; ok {
+			continue
+		}
+		seen[normalized] = struct{}{}
+		result = append(result, normalized)
+	}
+	return result, nil
+}
+
+func toTagResponse(tag *models.Tag) response.TagResponse {
+	return response.TagResponse{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		Color:     tag.Color,
+		CreatedAt: tag.CreatedAt,
+		UpdatedAt: tag.UpdatedAt,
+	}
+}
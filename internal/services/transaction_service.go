@@ -0,0 +1,624 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"gin-backend-app/internal/apperror"
+	"gin-backend-app/internal/dto/request"
+	"gin-backend-app/internal/dto/response"
+	"gin-backend-app/internal/metrics"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories"
+	"io"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultTransactionPageSize = 20
+	exportPageSize             = 500
+	maxImportRows              = 5000
+)
+
+var ErrTransactionNotFound = apperror.New(apperror.CodeTransactionNotFound, "transaction not found")
+
+// importColumns is the header of transaction import and export CSV files.
+// The splits column holds "category=amount" pairs and the tags column tag
+// names, both separated by ";".
+var importColumns = []string{"date", "type", "amount", "category", "description", "splits", "tags"}
+
+type TransactionService struct {
+	Tx              repositories.TxManager
+	TransactionRepo repositories.TransactionRepository
+	CategoryRepo    repositories.CategoryRepository
+	TagRepo         repositories.TagRepository
+	BudgetAlerts    *BudgetAlertService
+	Notifications   *NotificationService
+	Attachments     *AttachmentService
+}
+
+func NewTransactionService(tx repositories.TxManager, transactionRepo repositories.TransactionRepository, categoryRepo repositories.CategoryRepository, tagRepo repositories.TagRepository, budgetAlerts *BudgetAlertService, notifications *NotificationService, attachments *AttachmentService) *TransactionService {
+	return &TransactionService{Tx: tx, TransactionRepo: transactionRepo, CategoryRepo: categoryRepo, TagRepo: tagRepo, BudgetAlerts: budgetAlerts, Notifications: notifications, Attachments: attachments}
+}
+
+type splitLine struct {
+	CategoryID  uuid.UUID
+	Amount      float64
+	Description *string
+}
+
+// CreateTransaction stores the transaction together with any tags it
+// creates, so a rejected transaction leaves no new tags behind.
+func (s *TransactionService) CreateTransaction(ctx context.Context, userId uuid.UUID, req request.TransactionRequest) (*response.TransactionResponse, error) {
+	var transaction *models.Transaction
+	err := s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		transaction, err = s.transactionFromRequest(ctx, userId, req)
+		if err != nil {
+			return err
+		}
+		if err := s.TransactionRepo.Create(ctx, transaction); err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	metrics.TransactionsCreated(metrics.SourceAPI, 1)
+	s.checkBudgets(ctx, userId, transaction)
+
+	return s.GetTransaction(ctx, userId, transaction.ID)
+}
+
+func (s *TransactionService) UpdateTransaction(ctx context.Context, userId, transactionId uuid.UUID, req request.TransactionRequest) (*response.TransactionResponse, error) {
+	var transaction *models.Transaction
+	err := s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.TransactionRepo.FindByID(ctx, transactionId, userId)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return ErrTransactionNotFound
+		}
+
+		transaction, err = s.transactionFromRequest(ctx, userId, req)
+		if err != nil {
+			return err
+		}
+		transaction.ID = existing.ID
+		transaction.CreatedAt = existing.CreatedAt
+
+		if err := s.TransactionRepo.Update(ctx, transaction); err != nil {
+			return fmt.Errorf("failed to update transaction: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.checkBudgets(ctx, userId, transaction)
+
+	return s.GetTransaction(ctx, userId, transaction.ID)
+}
+
+func (s *TransactionService) GetTransaction(ctx context.Context, userId, transactionId uuid.UUID) (*response.TransactionResponse, error) {
+	transaction, err := s.TransactionRepo.FindByID(ctx, transactionId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if transaction == nil {
+		return nil, ErrTransactionNotFound
+	}
+
+	res := toTransactionResponse(transaction)
+	return &res, nil
+}
+
+func (s *TransactionService) ListTransactions(ctx context.Context, userId uuid.UUID, query request.ListTransactionsQuery) (*response.TransactionListResponse, error) {
+	filter, err := transactionFilterFromQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Limit = query.Limit
+	if filter.Limit == 0 {
+		filter.Limit = defaultTransactionPageSize
+	}
+	page := query.Page
+	if page == 0 {
+		page = 1
+	}
+	filter.Offset = (page - 1) * filter.Limit
+
+	transactions, total, err := s.TransactionRepo.List(ctx, userId, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.TransactionResponse, 0, len(transactions))
+	for _, transaction := range transactions {
+		items = append(items, toTransactionResponse(transaction))
+	}
+
+	return &response.TransactionListResponse{
+		Items: items,
+		Total: total,
+		Page:  page,
+		Limit: filter.Limit,
+	}, nil
+}
+
+// ExportTransactions writes all transactions matching the query as CSV in
+// the same format accepted by ImportTransactions.
+func (s *TransactionService) ExportTransactions(ctx context.Context, userId uuid.UUID, query request.ListTransactionsQuery, w io.Writer) error {
+	filter, err := transactionFilterFromQuery(query)
+	if err != nil {
+		return err
+	}
+	filter.Limit = exportPageSize
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(importColumns); err != nil {
+		return err
+	}
+
+	for {
+		transactions, _, err := s.TransactionRepo.List(ctx, userId, filter)
+		if err != nil {
+			return fmt.Errorf("failed to load transactions: %w", err)
+		}
+
+		for _, transaction := range transactions {
+			if err := writer.Write(transactionRecord(transaction)); err != nil {
+				return err
+			}
+		}
+
+		if len(transactions) < filter.Limit {
+			break
+		}
+		filter.Offset += filter.Limit
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// DeleteTransaction deletes the transaction; its attachment rows go with it
+// through the foreign key cascade and their stored files are removed once
+// the delete is committed.
+func (s *TransactionService) DeleteTransaction(ctx context.Context, userId, transactionId uuid.UUID) error {
+	var attachments []*models.Attachment
+	err := s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if s.Attachments != nil {
+			var err error
+			if attachments, err = s.Attachments.ListForTransaction(ctx, userId, transactionId); err != nil {
+				return fmt.Errorf("failed to list attachments: %w", err)
+			}
+		}
+		return s.TransactionRepo.Delete(ctx, transactionId, userId)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTransactionNotFound
+		}
+		return err
+	}
+
+	if s.Attachments != nil {
+		s.Attachments.RemoveObjects(ctx, attachments)
+	}
+	return nil
+}
+
+// ImportTransactions reads a CSV file with the importColumns header. Valid
+// rows are stored in a single batch; invalid rows are skipped and reported.
+// The batch and the tags it creates are committed in one transaction.
+func (s *TransactionService) ImportTransactions(ctx context.Context, userId uuid.UUID, file io.Reader) (*response.TransactionImportResponse, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, apperror.Field("file", "csv", "import file is empty or not a valid csv")
+	}
+	columns, err := importColumnIndex(header)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.TransactionImportResponse{}
+	categories := make(map[string]*models.Category)
+	var transactions []*models.Transaction
+
+	err = s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		for row := 2; ; row++ {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if row-1 > maxImportRows {
+				return apperror.Field("file", "max", fmt.Sprintf("import file exceeds %d rows", maxImportRows))
+			}
+			if err != nil {
+				result.Errors = append(result.Errors, response.TransactionImportRowError{Row: row, Message: err.Error()})
+				continue
+			}
+
+			transaction, err := s.transactionFromRecord(ctx, userId, record, columns, categories)
+			if err != nil {
+				result.Errors = append(result.Errors, response.TransactionImportRowError{Row: row, Message: err.Error()})
+				continue
+			}
+			transactions = append(transactions, transaction)
+		}
+
+		if err := s.TransactionRepo.CreateBatch(ctx, transactions); err != nil {
+			return fmt.Errorf("failed to import transactions: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	metrics.TransactionsCreated(metrics.SourceImport, len(transactions))
+	s.checkBudgets(ctx, userId, transactions...)
+
+	result.Imported = len(transactions)
+	if s.Notifications != nil {
+		err := s.Notifications.Notify(ctx, userId, models.NotificationImportFinished,
+			map[string]any{"imported": result.Imported, "rejected": len(result.Errors)},
+			nil, []any{result.Imported, len(result.Errors)})
+		if err != nil {
+			slog.ErrorContext(ctx, "import notification failed", "error", err)
+		}
+	}
+	return result, nil
+}
+
+func (s *TransactionService) transactionFromRequest(ctx context.Context, userId uuid.UUID, req request.TransactionRequest) (*models.Transaction, error) {
+	date, err := time.Parse(dateLayout, req.Date)
+	if err != nil {
+		return nil, apperror.Field("date", "datetime", "invalid date")
+	}
+
+	var categoryID *uuid.UUID
+	if req.CategoryID != "" {
+		parsed, err := uuid.Parse(req.CategoryID)
+		if err != nil {
+			return nil, apperror.Field("category_id", "uuid", "invalid category id")
+		}
+		categoryID = &parsed
+	}
+
+	splits := make([]splitLine, 0, len(req.Splits))
+	for _, split := range req.Splits {
+		parsed, err := uuid.Parse(split.CategoryID)
+		if err != nil {
+			return nil, apperror.Field("splits", "uuid", "invalid split category id")
+		}
+		splits = append(splits, splitLine{CategoryID: parsed, Amount: split.Amount, Description: split.Description})
+	}
+
+	transaction, err := s.buildTransaction(ctx, userId, models.TransactionGroupType(req.Type), req.Amount, date, req.Description, categoryID, splits)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachTags(ctx, transaction, req.Tags); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+func (s *TransactionService) transactionFromRecord(ctx context.Context, userId uuid.UUID, record []string, columns map[string]int, categories map[string]*models.Category) (*models.Transaction, error) {
+	field := func(name string) string {
+		idx, ok := columns[name]
+		if !ok || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+
+	date, err := time.Parse(dateLayout, field("date"))
+	if err != nil {
+		return nil, apperror.Field("date", "datetime", "invalid date, expected YYYY-MM-DD")
+	}
+
+	txType := models.TransactionGroupType(strings.ToLower(field("type")))
+	if txType != models.TransactionGroupIncome && txType != models.TransactionGroupExpense {
+		return nil, apperror.Field("type", "oneof", "type must be income or expense")
+	}
+
+	amount, err := parseAmount(field("amount"))
+	if err != nil {
+		return nil, err
+	}
+
+	var description *string
+	if d := field("description"); d != "" {
+		description = &d
+	}
+
+	resolve := func(name string) (uuid.UUID, error) {
+		key := strings.ToLower(name) + "|" + string(txType)
+		category, ok := categories[key]
+		if !ok {
+			found, err := s.CategoryRepo.FindByName(ctx, userId, name, txType)
+			if err != nil {
+				return uuid.Nil, fmt.Errorf("failed to find category: %w", err)
+			}
+			categories[key] = found
+			category = found
+		}
+		if category == nil {
+			return uuid.Nil, apperror.Field("category", "exists", fmt.Sprintf("category %q not found", name))
+		}
+		return category.ID, nil
+	}
+
+	var categoryID *uuid.UUID
+	if name := field("category"); name != "" {
+		id, err := resolve(name)
+		if err != nil {
+			return nil, err
+		}
+		categoryID = &id
+	}
+
+	var splits []splitLine
+	if raw := field("splits"); raw != "" {
+		for _, part := range strings.Split(raw, ";") {
+			name, value, ok := strings.Cut(part, "=")
+			if !ok {
+				return nil, apperror.Field("splits", "format", fmt.Sprintf("invalid split %q, expected category=amount", part))
+			}
+			id, err := resolve(strings.TrimSpace(name))
+			if err != nil {
+				return nil, err
+			}
+			splitAmount, err := parseAmount(strings.TrimSpace(value))
+			if err != nil {
+				return nil, err
+			}
+			splits = append(splits, splitLine{CategoryID: id, Amount: splitAmount})
+		}
+	}
+
+	transaction, err := s.buildTransaction(ctx, userId, txType, amount, date, description, categoryID, splits)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	if raw := field("tags"); raw != "" {
+		tags = strings.Split(raw, ";")
+	}
+	if err := s.attachTags(ctx, transaction, tags); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// buildTransaction validates the category ownership and split lines and
+// returns the model ready to be stored. For split transactions without an
+// explicit category the parent category is the one of the largest split.
+func (s *TransactionService) buildTransaction(ctx context.Context, userId uuid.UUID, txType models.TransactionGroupType, amount float64, date time.Time, description *string, categoryID *uuid.UUID, splits []splitLine) (*models.Transaction, error) {
+	if amount <= 0 {
+		return nil, apperror.Field("amount", "gt", "amount must be greater than zero")
+	}
+
+	if categoryID == nil && len(splits) == 0 {
+		return nil, apperror.Field("category_id", "required_without", "category or splits are required")
+	}
+
+	transaction := &models.Transaction{
+		UserID:      userId,
+		Type:        txType,
+		Amount:      amount,
+		Description: description,
+		Date:        date,
+	}
+
+	if len(splits) > 0 {
+		var splitCents int64
+		largest := 0
+		for i, split := range splits {
+			if split.Amount <= 0 {
+				return nil, apperror.Field("splits", "gt", "split amount must be greater than zero")
+			}
+			if err := s.checkCategory(ctx, userId, split.CategoryID, txType); err != nil {
+				return nil, err
+			}
+			splitCents += toCents(split.Amount)
+			if split.Amount > splits[largest].Amount {
+				largest = i
+			}
+			transaction.Splits = append(transaction.Splits, models.TransactionSplit{
+				CategoryID:  split.CategoryID,
+				Amount:      split.Amount,
+				Description: split.Description,
+			})
+		}
+		if splitCents != toCents(amount) {
+			return nil, apperror.Field("splits", "sum", "split amounts must sum to the transaction amount")
+		}
+		if categoryID == nil {
+			categoryID = &splits[largest].CategoryID
+		}
+	}
+
+	if err := s.checkCategory(ctx, userId, *categoryID, txType); err != nil {
+		return nil, err
+	}
+	transaction.CategoryID = *categoryID
+
+	return transaction, nil
+}
+
+// checkBudgets runs budget threshold alerts for saved transactions. Alert
+// failures are logged only: the transaction itself is already committed.
+func (s *TransactionService) checkBudgets(ctx context.Context, userId uuid.UUID, transactions ...*models.Transaction) {
+	if s.BudgetAlerts == nil {
+		return
+	}
+	if err := s.BudgetAlerts.CheckTransactions(ctx, userId, transactions); err != nil {
+		slog.ErrorContext(ctx, "budget alerts failed", "error", err)
+	}
+}
+
+func (s *TransactionService) attachTags(ctx context.Context, transaction *models.Transaction, names []string) error {
+	normalized, err := normalizeTagNames(names)
+	if err != nil {
+		return err
+	}
+
+	tags, err := s.TagRepo.FindOrCreateByNames(ctx, transaction.UserID, normalized)
+	if err != nil {
+		return fmt.Errorf("failed to resolve tags: %w", err)
+	}
+	transaction.Tags = tags
+	return nil
+}
+
+func (s *TransactionService) checkCategory(ctx context.Context, userId, categoryId uuid.UUID, txType models.TransactionGroupType) error {
+	category, err := s.CategoryRepo.FindByID(ctx, categoryId, userId)
+	if err != nil {
+		return fmt.Errorf("failed to find category: %w", err)
+	}
+	if category == nil {
+		return apperror.Field("category_id", "exists", "category not found")
+	}
+	if category.GroupType != txType {
+		return apperror.Field("category_id", "type", fmt.Sprintf("category %q is not an %s category", category.Name, txType))
+	}
+	return nil
+}
+
+func transactionFilterFromQuery(query request.ListTransactionsQuery) (repositories.TransactionFilter, error) {
+	var filter repositories.TransactionFilter
+
+	if query.From != "" {
+		from, err := time.Parse(dateLayout, query.From)
+		if err != nil {
+			return filter, apperror.Field("from", "datetime", "invalid from date")
+		}
+		filter.From = &from
+	}
+	if query.To != "" {
+		to, err := time.Parse(dateLayout, query.To)
+		if err != nil {
+			return filter, apperror.Field("to", "datetime", "invalid to date")
+		}
+		filter.To = &to
+	}
+	if query.CategoryID != "" {
+		categoryID, err := uuid.Parse(query.CategoryID)
+		if err != nil {
+			return filter, apperror.Field("category_id", "uuid", "invalid category id")
+		}
+		filter.CategoryID = &categoryID
+	}
+	if query.Type != "" {
+		txType := models.TransactionGroupType(query.Type)
+		filter.Type = &txType
+	}
+	if query.Tags != "" {
+		tags, err := normalizeTagNames(strings.Split(query.Tags, ","))
+		if err != nil {
+			return filter, err
+		}
+		filter.Tags = tags
+	}
+
+	return filter, nil
+}
+
+func importColumnIndex(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range importColumns[:3] {
+		if _, ok := columns[required]; !ok {
+			return nil, apperror.Field("file", "columns", fmt.Sprintf("import file is missing the %q column, expected header: %s", required, strings.Join(importColumns, ",")))
+		}
+	}
+	return columns, nil
+}
+
+func parseAmount(value string) (float64, error) {
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || amount <= 0 || math.IsInf(amount, 0) {
+		return 0, apperror.Field("amount", "gt", fmt.Sprintf("invalid amount %q", value))
+	}
+	return amount, nil
+}
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func transactionRecord(transaction *models.Transaction) []string {
+	var description string
+	if transaction.Description != nil {
+		description = *transaction.Description
+	}
+
+	splits := make([]string, 0, len(transaction.Splits))
+	for _, split := range transaction.Splits {
+		splits = append(splits, split.Category.Name+"="+strconv.FormatFloat(split.Amount, 'f', 2, 64))
+	}
+
+	tags := make([]string, 0, len(transaction.Tags))
+	for _, tag := range transaction.Tags {
+		tags = append(tags, tag.Name)
+	}
+
+	return []string{
+		transaction.Date.Format(dateLayout),
+		string(transaction.Type),
+		strconv.FormatFloat(transaction.Amount, 'f', 2, 64),
+		transaction.Category.Name,
+		description,
+		strings.Join(splits, ";"),
+		strings.Join(tags, ";"),
+	}
+}
+
+func toTransactionResponse(transaction *models.Transaction) response.TransactionResponse {
+	res := response.TransactionResponse{
+		ID:           transaction.ID,
+		CategoryID:   transaction.CategoryID,
+		CategoryName: transaction.Category.Name,
+		Type:         string(transaction.Type),
+		Amount:       transaction.Amount,
+		Description:  transaction.Description,
+		Date:         transaction.Date,
+		CreatedAt:    transaction.CreatedAt,
+		UpdatedAt:    transaction.UpdatedAt,
+		Tags:         make([]string, 0, len(transaction.Tags)),
+	}
+	for _, tag := range transaction.Tags {
+		res.Tags = append(res.Tags, tag.Name)
+	}
+	for _, split := range transaction.Splits {
+		res.Splits = append(res.Splits, response.TransactionSplitResponse{
+			ID:           split.ID,
+			CategoryID:   split.CategoryID,
+			CategoryName: split.Category.Name,
+			Amount:       split.Amount,
+			Description:  split.Description,
+		})
+	}
+	return res
+}
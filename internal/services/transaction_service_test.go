@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories/fakes"
+	"gin-backend-app/internal/testutil/fixtures"
+	"gin-backend-app/pkg/storage"
+
+	"github.com/google/uuid"
+)
+
+func TestDeleteTransactionRemovesAttachmentObjects(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userID := uuid.New()
+	categories := fakes.NewCategoryRepository(fixtures.NewCategory(userID).Build())
+	transactions := fakes.NewTransactionRepository(categories)
+	attachmentRepo := fakes.NewAttachmentRepository()
+	attachments := NewAttachmentService(attachmentRepo, transactions, store, AttachmentConfig{})
+	service := NewTransactionService(fakes.TxManager{}, transactions, categories, fakes.NewTagRepository(transactions), nil, nil, attachments)
+
+	transaction := fixtures.NewTransaction(userID, uuid.New()).Build()
+	if err := transactions.Create(ctx, transaction); err != nil {
+		t.Fatal(err)
+	}
+	thumbnail := "receipts/thumb.jpg"
+	attachment := &models.Attachment{
+		UserID:        userID,
+		TransactionID: transaction.ID,
+		FileName:      "receipt.jpg",
+		ContentType:   "image/jpeg",
+		StorageKey:    "receipts/receipt.jpg",
+		ThumbnailKey:  &thumbnail,
+	}
+	for _, key := range []string{attachment.StorageKey, thumbnail} {
+		if err := store.Put(ctx, key, strings.NewReader("data"), 4, "image/jpeg"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := attachmentRepo.Create(ctx, attachment); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := service.DeleteTransaction(ctx, uuid.New(), transaction.ID); !errors.Is(err, ErrTransactionNotFound) {
+		t.Fatalf("delete by another user: error = %v, want ErrTransactionNotFound", err)
+	}
+	assertObject(t, store, attachment.StorageKey, true)
+
+	if err := service.DeleteTransaction(ctx, userID, transaction.ID); err != nil {
+		t.Fatalf("DeleteTransaction: %v", err)
+	}
+	assertObject(t, store, attachment.StorageKey, false)
+	assertObject(t, store, thumbnail, false)
+}
+
+func assertObject(t *testing.T, store storage.Storage, key string, want bool) {
+	t.Helper()
+
+	r, err := store.Get(context.Background(), key)
+	if err == nil {
+		io.Copy(io.Discard, r)
+		r.Close()
+	}
+	if exists := err == nil; exists != want {
+		t.Errorf("object %s exists = %v, want %v (error %v)", key, exists, want, err)
+	}
+}
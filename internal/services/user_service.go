@@ -1,71 +1,118 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"gin-backend-app/internal/apperror"
 	"gin-backend-app/internal/dto/request"
 	"gin-backend-app/internal/dto/response"
+	"gin-backend-app/internal/metrics"
 	"gin-backend-app/internal/models"
 	"gin-backend-app/internal/repositories"
+	"gin-backend-app/pkg/mailtemplate"
 	"gin-backend-app/pkg/utils"
-	"log"
+	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+var (
+	ErrUserNotFound       = apperror.New(apperror.CodeUserNotFound, "user not found")
+	ErrEmailTaken         = apperror.New(apperror.CodeUserEmailTaken, "email already exists")
+	ErrUsernameTaken      = apperror.New(apperror.CodeUserNameTaken, "username already exists")
+	ErrInvalidCredentials = apperror.New(apperror.CodeAuthInvalidCredentials, "invalid email or password")
+	ErrInvalidVerifyToken = apperror.New(apperror.CodeAuthTokenInvalid, "invalid or expired verification token")
+	ErrInvalidResetOTP    = apperror.New(apperror.CodeOTPInvalid, "invalid or expired otp")
+	ErrEmailUnavailable   = apperror.New(apperror.CodeServiceUnavailable, "email service is not configured")
+)
+
 type UserService struct {
-	UserRepo *repositories.UserRepository
-	UserTokenEmail *repositories.UserTokenRepository
+	Tx repositories.TxManager
+	UserRepo repositories.UserRepository
+	UserTokenEmail repositories.UserTokenRepository
 	EmailService *EmailVerficationService
+	Passwords PasswordPolicy
+	Attachments *AttachmentService
+}
+
+func NewUserService(tx repositories.TxManager, userRepo repositories.UserRepository, userTokenEmailRepo repositories.UserTokenRepository, emailService *EmailVerficationService, passwords PasswordPolicy, attachments *AttachmentService) *UserService {
+    return &UserService{Tx: tx, UserRepo: userRepo, UserTokenEmail: userTokenEmailRepo, EmailService: emailService, Passwords: passwords, Attachments: attachments}
+}
+
+func (s *UserService) CreateUser(ctx context.Context, req request.CreateUserRequest) (*response.LoginResponse, error) {
+	return s.createUser(ctx, req, false)
 }
 
-func NewUserService(userRepo *repositories.UserRepository, userTokenEmailRepo *repositories.UserTokenRepository, emailService *EmailVerficationService) *UserService {
-    return &UserService{UserRepo: userRepo, UserTokenEmail: userTokenEmailRepo, EmailService: emailService}
+// CreateVerifiedUser creates a user whose email is already verified, so no
+// verification email is queued. It is meant for operators, not sign ups.
+func (s *UserService) CreateVerifiedUser(ctx context.Context, req request.CreateUserRequest) (*response.LoginResponse, error) {
+	return s.createUser(ctx, req, true)
 }
 
-func (s *UserService) CreateUser(req request.CreateUserRequest) (*response.LoginResponse, error) {
+func (s *UserService) createUser(ctx context.Context, req request.CreateUserRequest, verified bool) (*response.LoginResponse, error) {
 	email := req.Email
 	name := req.Name
-	existingUser, err := s.UserRepo.FindByEmailOrUsername(email, name)
+	existingUser, err := s.UserRepo.FindByEmailOrUsername(ctx, email, name)
 	if err != nil {
 		return nil ,err
 	}
 
 	if existingUser != nil {
 		if existingUser.Email == email {
-			return nil, errors.New("email already exists")
+			return nil, ErrEmailTaken
 		}
 		if existingUser.Name == name {
-			return nil, errors.New("username already exists")
+			return nil, ErrUsernameTaken
 		}
 	}
 
+	if err := s.Passwords.Check(ctx, "password", req.Password, name, email); err != nil {
+		return nil, err
+	}
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return nil, errors.New("failed to hashed password")
+		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 	user := models.User{
 		Email: email,
 		Name: name,
 		Password: string(hashedPassword),
+		Locale: mailtemplate.NormalizeLocale(req.Locale),
+	}
+	if verified {
+		now := time.Now()
+		user.IsEmailVerified = true
+		user.EmailVerifiedAt = &now
 	}
 
-	if err := s.UserRepo.Create(&user); err != nil {
-        return nil, errors.New("failed to create user")
-    }
+	// The user, its verification token and the queued email are committed
+	// together so a registration never ends up without a verification code.
+	err = s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.UserRepo.Create(ctx, &user); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		if s.EmailService != nil && !verified {
+			if err := s.EmailService.SendEmailVerification(ctx, &user, models.TokenTypeEmailVerification); err != nil {
+				return fmt.Errorf("failed to queue verification email: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	metrics.UserRegistered()
+
 	token, err := utils.CreateToken(&user)
 
 	if err != nil {
-		return nil, errors.New("token generation failed")
+		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
 
-	if s.EmailService != nil {
-        if err := s.EmailService.SendEmailVerification(&user, models.TokenTypeEmailVerification); err != nil {
-            log.Printf("Failed to send verification email: %v", err)
-        }
-    }
-
     return &response.LoginResponse{
 		User: response.UserResponse{
 			ID: user.ID,
@@ -77,23 +124,24 @@ func (s *UserService) CreateUser(req request.CreateUserRequest) (*response.Login
     }, nil
 }
 
-func (s *UserService) LoginUser(req request.LoginUserRequest) (*response.LoginResponse, error) {
-	user, err := s.UserRepo.FindByEmail(req.Email)
+func (s *UserService) LoginUser(ctx context.Context, req request.LoginUserRequest) (*response.LoginResponse, error) {
+	user, err := s.UserRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
-        return nil, errors.New("invalid credentials")
+        return nil, err
     }
 
+    // Unknown email and wrong password look the same to the client.
     if user == nil {
-        return nil, errors.New("user not found")
+        return nil, ErrInvalidCredentials
     }
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-        return nil, errors.New("wrong password")
+        return nil, ErrInvalidCredentials
 	} 
 	
 	token, err := utils.CreateToken(user)
 	if err != nil {
-		return nil, errors.New("failed to generate token")
+		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
 	return &response.LoginResponse{
         User: response.UserResponse{
@@ -106,64 +154,230 @@ func (s *UserService) LoginUser(req request.LoginUserRequest) (*response.LoginRe
     }, nil
 }
 
-func (s *UserService) SendOtpToResetPassword (email string) error {
-	user, err := s.UserRepo.FindByEmail(email) 
+func (s *UserService) SendOtpToResetPassword (ctx context.Context, email string) error {
+	user, err := s.UserRepo.FindByEmail(ctx, email) 
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("email didnt exist")
-		}
 		return fmt.Errorf("failed to find user by email: %w", err)
 	}
+	if user == nil {
+		return ErrUserNotFound
+	}
 
 	if s.EmailService == nil {
-		return errors.New("email service is not configured")
+		return ErrEmailUnavailable
 	}
 
-	if err := s.EmailService.SendEmailVerification(user, models.TokenTypePasswordReset); err != nil {
+	if err := s.EmailService.SendEmailVerification(ctx, user, models.TokenTypePasswordReset); err != nil {
 		return fmt.Errorf("failed to send password reset email: %w", err)
 	}
 
 	return nil
 }
 
-func (s *UserService) GenerateAndSetVerificationToken(email, tokenOtp string) (string, error) {
-	user, err := s.UserRepo.FindByEmail(email) 
+func (s *UserService) GenerateAndSetVerificationToken(ctx context.Context, email, tokenOtp string) (string, error) {
+	user, err := s.UserRepo.FindByEmail(ctx, email) 
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return "" ,errors.New("email didnt exist")
-		}
 		return "",fmt.Errorf("failed to find user by email: %w", err)
 	}
+	if user == nil {
+		return "", ErrUserNotFound
+	}
 
-	verifyToken, err := s.UserTokenEmail.GenerateAndSetVerificationTokenByOTP(tokenOtp, email, models.TokenTypePasswordReset, user.ID) 
+	verifyToken, err := s.UserTokenEmail.GenerateAndSetVerificationTokenByOTP(ctx, tokenOtp, email, models.TokenTypePasswordReset, user.ID) 
 
+	if errors.Is(err, repositories.ErrInvalidOTP) {
+		return "", ErrInvalidResetOTP
+	}
 	if err != nil {
-		return "", errors.New(err.Error())
+		return "", err
 	}
 	return verifyToken, nil
 }
 
 
-func (s *UserService) ValidateAndChangePassword (verificationToken, newPassword, confirmPassword string) error {
-	user, err := s.UserTokenEmail.ValidateTokenAndGetUser(verificationToken)
+// ValidateAndChangePassword changes the password and consumes the verify
+// token in one transaction, so a reset token works exactly once.
+func (s *UserService) ValidateAndChangePassword (ctx context.Context, verificationToken, newPassword, confirmPassword string) error {
+	if newPassword != confirmPassword {
+		return apperror.Field("confirm_password", "eqfield", "the new password and confirmation password must match")
+	}
+
+	// Hash before opening the transaction so it is not held for the bcrypt
+	// work.
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+
 	if err != nil {
 		return err
 	}
 
-	if newPassword != confirmPassword {
-		return errors.New("the new password and confirmation password must match")
+	err = s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err := s.UserTokenEmail.ValidateTokenAndGetUser(ctx, verificationToken)
+		if err != nil {
+			return err
+		}
+		// Checked inside the transaction, where the user is known; a
+		// rejected password leaves the token unused.
+		if err := s.Passwords.Check(ctx, "new_password", newPassword, user.Name, user.Email); err != nil {
+			return err
+		}
+		if err := s.UserRepo.ChangePassword(ctx, string(hashedPassword), user.ID); err != nil {
+			return err
+		}
+		return s.UserTokenEmail.MarkVerifyTokenUsed(ctx, verificationToken)
+	})
+	if errors.Is(err, repositories.ErrInvalidVerifyToken) {
+		return ErrInvalidVerifyToken
 	}
+	return err
+}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 
+// ChangePassword changes the password of a signed in user, who confirms
+// it with their current password.
+func (s *UserService) ChangePassword(ctx context.Context, userId uuid.UUID, req request.UpdatePasswordRequest) error {
+	if req.NewPassword != req.ConfirmPassword {
+		return apperror.Field("confirm_password", "eqfield", "the new password and confirmation password must match")
+	}
+
+	user, err := s.UserRepo.FindByID(ctx, userId)
 	if err != nil {
 		return err
 	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword)); err != nil {
+		return apperror.Field("current_password", "current", "current password is incorrect")
+	}
+
+	if err := s.Passwords.Check(ctx, "new_password", req.NewPassword, user.Name, user.Email); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	return s.UserRepo.ChangePassword(ctx, string(hashedPassword), user.ID)
+}
 
-	err = s.UserRepo.ChangePassword(string(hashedPassword), user.ID)
+func (s *UserService) GetPreferences(ctx context.Context, userId uuid.UUID) (*response.UserPreferencesResponse, error) {
+	user, err := s.UserRepo.FindByID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return toUserPreferencesResponse(user), nil
+}
+
+func (s *UserService) UpdatePreferences(ctx context.Context, userId uuid.UUID, req request.UpdatePreferencesRequest) (*response.UserPreferencesResponse, error) {
+	user, err := s.UserRepo.FindByID(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if req.Locale != nil {
+		user.Locale = mailtemplate.NormalizeLocale(*req.Locale)
+	}
+	if req.DigestWeekly != nil {
+		user.DigestWeekly = *req.DigestWeekly
+	}
+	if req.DigestMonthly != nil {
+		user.DigestMonthly = *req.DigestMonthly
+	}
+
+	if err := s.UserRepo.UpdatePreferences(ctx, user.ID, user.Locale, user.DigestWeekly, user.DigestMonthly); err != nil {
+		return nil, fmt.Errorf("failed to update preferences: %w", err)
+	}
+
+	return toUserPreferencesResponse(user), nil
+}
+
+func toUserPreferencesResponse(user *models.User) *response.UserPreferencesResponse {
+	return &response.UserPreferencesResponse{
+		Locale:        user.Locale,
+		DigestWeekly:  user.DigestWeekly,
+		DigestMonthly: user.DigestMonthly,
+	}
+}
+
+// FindUser resolves an operator supplied identifier, either a user id or an
+// email address.
+func (s *UserService) FindUser(ctx context.Context, identifier string) (*models.User, error) {
+	var (
+		user *models.User
+		err  error
+	)
+	if id, parseErr := uuid.Parse(identifier); parseErr == nil {
+		user, err = s.UserRepo.FindByID(ctx, id)
+	} else {
+		user, err = s.UserRepo.FindByEmail(ctx, identifier)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// MarkEmailVerified verifies the user's email without an OTP.
+func (s *UserService) MarkEmailVerified(ctx context.Context, userId uuid.UUID) error {
+	return s.UserRepo.MarkEmailVerified(ctx, userId)
+}
+
+// SetPassword replaces the user's password without a reset token. The new
+// password must still satisfy the password policy.
+func (s *UserService) SetPassword(ctx context.Context, userId uuid.UUID, newPassword string) error {
+	user, err := s.UserRepo.FindByID(ctx, userId)
 	if err != nil {
 		return err
 	}
-	return nil
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	if err := s.Passwords.Check(ctx, "password", newPassword, user.Name, user.Email); err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	return s.UserRepo.ChangePassword(ctx, string(hashedPassword), userId)
 }
 
+// DeleteUser removes the user; their data is removed by the ON DELETE
+// CASCADE foreign keys and their attachment files once that is committed.
+func (s *UserService) DeleteUser(ctx context.Context, userId uuid.UUID) error {
+	var attachments []*models.Attachment
+	err := s.Tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if s.Attachments != nil {
+			var err error
+			if attachments, err = s.Attachments.ListForUser(ctx, userId); err != nil {
+				return fmt.Errorf("failed to list attachments: %w", err)
+			}
+		}
+		return s.UserRepo.Delete(ctx, userId)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	if s.Attachments != nil {
+		s.Attachments.RemoveObjects(ctx, attachments)
+	}
+	return nil
+}
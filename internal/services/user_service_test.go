@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gin-backend-app/internal/apperror"
+	"gin-backend-app/internal/dto/request"
+	"gin-backend-app/internal/models"
+	"gin-backend-app/internal/repositories/fakes"
+	"gin-backend-app/internal/testutil/fixtures"
+	"gin-backend-app/pkg/utils"
+)
+
+type userServiceTest struct {
+	service *UserService
+	users   *fakes.UserRepository
+	tokens  *fakes.UserTokenRepository
+	outbox  *fakes.EmailOutboxRepository
+}
+
+func newUserServiceTest(t *testing.T, users ...*models.User) userServiceTest {
+	t.Helper()
+	utils.SetJWTSecret("test-secret")
+
+	st := userServiceTest{users: fakes.NewUserRepository(users...), outbox: fakes.NewEmailOutboxRepository()}
+	st.tokens = fakes.NewUserTokenRepository(st.users)
+	outbox := NewEmailOutboxService(st.outbox, nil, DefaultEmailOutboxConfig())
+	verification := NewEmailVerificationService(fakes.TxManager{}, st.users, st.tokens, outbox, "http://localhost")
+	passwords := PasswordPolicy{MinLength: 8, MinCharClasses: 3}
+	st.service = NewUserService(fakes.TxManager{}, st.users, st.tokens, verification, passwords, nil)
+	return st
+}
+
+func TestCreateUserQueuesVerificationEmail(t *testing.T) {
+	st := newUserServiceTest(t)
+
+	created, err := st.service.CreateUser(context.Background(), request.CreateUserRequest{
+		Name:     "alice",
+		Email:    "alice@example.com",
+		Password: "Correct-Horse-9",
+	})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	user, _ := st.users.FindByID(context.Background(), created.User.ID)
+	if user.IsEmailVerified {
+		t.Error("user is verified, want unverified")
+	}
+	if got := len(st.outbox.Messages()); got != 1 {
+		t.Errorf("queued %d emails, want 1", got)
+	}
+	if got := len(st.tokens.Tokens()); got != 1 {
+		t.Errorf("stored %d tokens, want 1", got)
+	}
+}
+
+func TestCreateVerifiedUserQueuesNoEmail(t *testing.T) {
+	st := newUserServiceTest(t)
+
+	created, err := st.service.CreateVerifiedUser(context.Background(), request.CreateUserRequest{
+		Name:     "bob",
+		Email:    "bob@example.com",
+		Password: "Correct-Horse-9",
+	})
+	if err != nil {
+		t.Fatalf("CreateVerifiedUser: %v", err)
+	}
+
+	user, _ := st.users.FindByID(context.Background(), created.User.ID)
+	if !user.IsEmailVerified || user.EmailVerifiedAt == nil {
+		t.Error("user is not verified")
+	}
+	if got := len(st.outbox.Messages()); got != 0 {
+		t.Errorf("queued %d emails, want 0", got)
+	}
+	if got := len(st.tokens.Tokens()); got != 0 {
+		t.Errorf("stored %d tokens, want 0", got)
+	}
+}
+
+func TestCreateUserRejectsWeakPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		rule     string
+	}{
+		{"too short", "Ab1!", "min"},
+		{"one class", "abcdefghijk", "char_classes"},
+		{"contains name", "Carol-2024!", "personal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newUserServiceTest(t)
+
+			_, err := st.service.CreateUser(context.Background(), request.CreateUserRequest{
+				Name:     "carol",
+				Email:    "carol@example.com",
+				Password: tt.password,
+			})
+			assertPasswordError(t, err, apperror.CodePasswordTooWeak, "password", tt.rule)
+			if user, _ := st.users.FindByEmail(context.Background(), "carol@example.com"); user != nil {
+				t.Error("user was created")
+			}
+		})
+	}
+}
+
+func TestSetPasswordChecksPolicy(t *testing.T) {
+	user := fixtures.NewUser().Named("dave").Build()
+	st := newUserServiceTest(t, user)
+	before := user.Password
+
+	err := st.service.SetPassword(context.Background(), user.ID, "short")
+	assertPasswordError(t, err, apperror.CodePasswordTooWeak, "password", "min")
+	if stored, _ := st.users.FindByID(context.Background(), user.ID); stored.Password != before {
+		t.Error("password changed after a policy violation")
+	}
+
+	if err := st.service.SetPassword(context.Background(), user.ID, "Correct-Horse-9"); err != nil {
+		t.Fatalf("SetPassword: %v", err)
+	}
+	if stored, _ := st.users.FindByID(context.Background(), user.ID); stored.Password == before {
+		t.Error("password did not change")
+	}
+}
+
+func assertPasswordError(t *testing.T, err error, code apperror.Code, field, rule string) {
+	t.Helper()
+
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) {
+		t.Fatalf("error = %v, want an apperror", err)
+	}
+	if appErr.Code != code {
+		t.Errorf("code = %s, want %s", appErr.Code, code)
+	}
+	if len(appErr.Details) != 1 || appErr.Details[0].Field != field || appErr.Details[0].Rule != rule {
+		t.Errorf("details = %+v, want %s on %s", appErr.Details, rule, field)
+	}
+}
@@ -0,0 +1,50 @@
+package fixtures
+
+import (
+	"gin-backend-app/internal/models"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CategoryBuilder struct {
+	category models.Category
+}
+
+// NewCategory starts an expense category with a unique name owned by userID.
+func NewCategory(userID uuid.UUID) *CategoryBuilder {
+	return &CategoryBuilder{category: models.Category{
+		UserID:    userID,
+		Name:      uniqueName("Category"),
+		GroupType: models.TransactionGroupExpense,
+		Color:     "#000000",
+	}}
+}
+
+func (b *CategoryBuilder) Named(name string) *CategoryBuilder {
+	b.category.Name = name
+	return b
+}
+
+func (b *CategoryBuilder) Income() *CategoryBuilder {
+	b.category.GroupType = models.TransactionGroupIncome
+	return b
+}
+
+func (b *CategoryBuilder) Expense() *CategoryBuilder {
+	b.category.GroupType = models.TransactionGroupExpense
+	return b
+}
+
+func (b *CategoryBuilder) Build() *models.Category {
+	category := b.category
+	return &category
+}
+
+func (b *CategoryBuilder) Create(t testing.TB, db *gorm.DB) *models.Category {
+	t.Helper()
+	category := b.Build()
+	create(t, db, category)
+	return category
+}
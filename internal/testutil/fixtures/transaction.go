@@ -0,0 +1,82 @@
+package fixtures
+
+import (
+	"gin-backend-app/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TransactionBuilder struct {
+	transaction models.Transaction
+}
+
+// NewTransaction starts a 10,000 expense dated today in categoryID. The type
+// should match the category's group; use Income for income categories.
+func NewTransaction(userID, categoryID uuid.UUID) *TransactionBuilder {
+	return &TransactionBuilder{transaction: models.Transaction{
+		UserID:     userID,
+		CategoryID: categoryID,
+		Type:       models.TransactionGroupExpense,
+		Amount:     10000,
+		Date:       today(),
+	}}
+}
+
+func (b *TransactionBuilder) Amount(amount float64) *TransactionBuilder {
+	b.transaction.Amount = amount
+	return b
+}
+
+// On sets the booking date; the time of day is dropped like the date column
+// does.
+func (b *TransactionBuilder) On(date time.Time) *TransactionBuilder {
+	b.transaction.Date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return b
+}
+
+func (b *TransactionBuilder) Income() *TransactionBuilder {
+	b.transaction.Type = models.TransactionGroupIncome
+	return b
+}
+
+func (b *TransactionBuilder) Description(description string) *TransactionBuilder {
+	b.transaction.Description = &description
+	return b
+}
+
+// Split adds a split line; the splits should add up to the amount.
+func (b *TransactionBuilder) Split(categoryID uuid.UUID, amount float64) *TransactionBuilder {
+	b.transaction.Splits = append(b.transaction.Splits, models.TransactionSplit{
+		CategoryID: categoryID,
+		Amount:     amount,
+	})
+	return b
+}
+
+// Tags attaches existing tags.
+func (b *TransactionBuilder) Tags(tags ...models.Tag) *TransactionBuilder {
+	b.transaction.Tags = append(b.transaction.Tags, tags...)
+	return b
+}
+
+func (b *TransactionBuilder) Build() *models.Transaction {
+	transaction := b.transaction
+	transaction.Splits = append([]models.TransactionSplit(nil), b.transaction.Splits...)
+	transaction.Tags = append([]models.Tag(nil), b.transaction.Tags...)
+	return &transaction
+}
+
+func (b *TransactionBuilder) Create(t testing.TB, db *gorm.DB) *models.Transaction {
+	t.Helper()
+	transaction := b.Build()
+	create(t, db, transaction)
+	return transaction
+}
+
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
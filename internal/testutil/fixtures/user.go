@@ -0,0 +1,88 @@
+package fixtures
+
+import (
+	"fmt"
+	"gin-backend-app/internal/models"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// defaultPasswordHash is computed once; bcrypt at the default cost would
+// dominate the run time of tests that create many users.
+var defaultPasswordHash = func() string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(DefaultPassword), bcrypt.MinCost)
+	if err != nil {
+		panic(err)
+	}
+	return string(hash)
+}()
+
+type UserBuilder struct {
+	user models.User
+}
+
+// NewUser starts an unverified user with a unique name and email and
+// DefaultPassword.
+func NewUser() *UserBuilder {
+	n := next()
+	return &UserBuilder{user: models.User{
+		Name:     fmt.Sprintf("User %d", n),
+		Email:    fmt.Sprintf("user%d@example.com", n),
+		Password: defaultPasswordHash,
+		Locale:   "en",
+	}}
+}
+
+func (b *UserBuilder) Named(name string) *UserBuilder {
+	b.user.Name = name
+	return b
+}
+
+func (b *UserBuilder) Email(email string) *UserBuilder {
+	b.user.Email = email
+	return b
+}
+
+// Password sets the plain-text password; it is stored hashed.
+func (b *UserBuilder) Password(password string) *UserBuilder {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		panic(err)
+	}
+	b.user.Password = string(hash)
+	return b
+}
+
+func (b *UserBuilder) Verified() *UserBuilder {
+	now := time.Now()
+	b.user.IsEmailVerified = true
+	b.user.EmailVerifiedAt = &now
+	return b
+}
+
+func (b *UserBuilder) Locale(locale string) *UserBuilder {
+	b.user.Locale = locale
+	return b
+}
+
+// Digest subscribes the user to the weekly and/or monthly digest.
+func (b *UserBuilder) Digest(weekly, monthly bool) *UserBuilder {
+	b.user.DigestWeekly = weekly
+	b.user.DigestMonthly = monthly
+	return b
+}
+
+func (b *UserBuilder) Build() *models.User {
+	user := b.user
+	return &user
+}
+
+func (b *UserBuilder) Create(t testing.TB, db *gorm.DB) *models.User {
+	t.Helper()
+	user := b.Build()
+	create(t, db, user)
+	return user
+}
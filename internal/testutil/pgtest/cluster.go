@@ -0,0 +1,110 @@
+package pgtest
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// ErrNoPostgres is returned when neither TEST_DATABASE_URL nor the Postgres
+// server binaries are available.
+var ErrNoPostgres = errors.New("postgres binaries not found; install postgres or set TEST_DATABASE_URL or PG_BIN")
+
+// Cluster is a throwaway Postgres cluster in a temporary directory, owned by
+// the test process.
+type Cluster struct {
+	Dir  string
+	Port int
+	bin  string
+}
+
+// StartCluster runs initdb and pg_ctl from PG_BIN, PATH or a standard install
+// location and starts a server on a free localhost port. Durability is turned
+// off; the data is deleted by Stop.
+func StartCluster() (*Cluster, error) {
+	bin, err := findBin()
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "pgtest-")
+	if err != nil {
+		return nil, err
+	}
+	c := &Cluster{Dir: dir, bin: bin}
+
+	port, err := freePort()
+	if err != nil {
+		c.remove()
+		return nil, err
+	}
+	c.Port = port
+
+	dataDir := filepath.Join(dir, "data")
+	if err := c.run("initdb", "-D", dataDir, "-U", "postgres", "-A", "trust", "-E", "UTF8", "--no-sync"); err != nil {
+		c.remove()
+		return nil, err
+	}
+
+	options := fmt.Sprintf("-p %d -k %s -c listen_addresses=127.0.0.1 -c fsync=off -c synchronous_commit=off -c full_page_writes=off", port, dir)
+	if err := c.run("pg_ctl", "-D", dataDir, "-l", filepath.Join(dir, "postgres.log"), "-o", options, "-w", "start"); err != nil {
+		c.remove()
+		return nil, err
+	}
+	return c, nil
+}
+
+// DSN returns the connection string of the cluster's postgres database.
+func (c *Cluster) DSN() string {
+	return fmt.Sprintf("host=127.0.0.1 port=%d user=postgres dbname=postgres sslmode=disable", c.Port)
+}
+
+// Stop shuts the server down and deletes its data directory.
+func (c *Cluster) Stop() error {
+	err := c.run("pg_ctl", "-D", filepath.Join(c.Dir, "data"), "-m", "immediate", "-w", "stop")
+	c.remove()
+	return err
+}
+
+func (c *Cluster) run(name string, args ...string) error {
+	output, err := exec.Command(filepath.Join(c.bin, name), args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s failed: %w\n%s", name, err, output)
+	}
+	return nil
+}
+
+func (c *Cluster) remove() {
+	os.RemoveAll(c.Dir)
+}
+
+// findBin returns the directory holding initdb and pg_ctl.
+func findBin() (string, error) {
+	if dir := os.Getenv("PG_BIN"); dir != "" {
+		return dir, nil
+	}
+	if path, err := exec.LookPath("pg_ctl"); err == nil {
+		return filepath.Dir(path), nil
+	}
+
+	// Debian and Ubuntu keep the server binaries out of PATH.
+	dirs, _ := filepath.Glob("/usr/lib/postgresql/*/bin")
+	for _, dir := range dirs {
+		if _, err := os.Stat(filepath.Join(dir, "pg_ctl")); err == nil {
+			return dir, nil
+		}
+	}
+	return "", ErrNoPostgres
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,92 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// GormPlugin records a client span for every GORM statement, child of the
+// span carried by the statement context. Only the SQL with placeholders is
+// recorded, never the bound values.
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("tracing:before_create", startSpan("create")),
+		cb.Create().After("gorm:create").Register("tracing:after_create", endSpan),
+		cb.Query().Before("gorm:query").Register("tracing:before_query", startSpan("query")),
+		cb.Query().After("gorm:query").Register("tracing:after_query", endSpan),
+		cb.Update().Before("gorm:update").Register("tracing:before_update", startSpan("update")),
+		cb.Update().After("gorm:update").Register("tracing:after_update", endSpan),
+		cb.Delete().Before("gorm:delete").Register("tracing:before_delete", startSpan("delete")),
+		cb.Delete().After("gorm:delete").Register("tracing:after_delete", endSpan),
+		cb.Row().Before("gorm:row").Register("tracing:before_row", startSpan("row")),
+		cb.Row().After("gorm:row").Register("tracing:after_row", endSpan),
+		cb.Raw().Before("gorm:raw").Register("tracing:before_raw", startSpan("raw")),
+		cb.Raw().After("gorm:raw").Register("tracing:after_raw", endSpan),
+	)
+}
+
+func startSpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil || !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+			// Statements outside a traced request or job would all be
+			// root spans of their own.
+			return
+		}
+		spanCtx, _ := Tracer().Start(ctx, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemNamePostgreSQL),
+		)
+		db.Statement.Context = spanCtx
+		db.InstanceSet(parentKey, ctx)
+	}
+}
+
+// parentKey holds the statement context from before startSpan, restored by
+// endSpan.
+const parentKey = "tracing:parent"
+
+func endSpan(db *gorm.DB) {
+	parent, ok := db.InstanceGet(parentKey)
+	if !ok {
+		return
+	}
+	span := trace.SpanFromContext(db.Statement.Context)
+	db.Statement.Context = parent.(context.Context)
+
+	query := db.Statement.SQL.String()
+	operation, _, _ := strings.Cut(strings.TrimSpace(query), " ")
+	operation = strings.ToUpper(operation)
+	name := operation
+	if db.Statement.Table != "" {
+		name += " " + db.Statement.Table
+		span.SetAttributes(semconv.DBCollectionName(db.Statement.Table))
+	}
+	if name != "" {
+		span.SetName(name)
+	}
+	span.SetAttributes(
+		semconv.DBOperationName(operation),
+		semconv.DBQueryText(query),
+		semconv.DBResponseReturnedRows(int(db.RowsAffected)),
+	)
+
+	if err := db.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,40 @@
+package tracing
+
+import (
+	"context"
+	"gin-backend-app/pkg/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedMailer records a span around every send of the mailer it wraps.
+type tracedMailer struct {
+	utils.Mailer
+	driver string
+}
+
+// TraceMailer returns m recording a "mail.send" span per message. driver
+// names the backend on the span; the recipient is left out.
+func TraceMailer(m utils.Mailer, driver string) utils.Mailer {
+	return tracedMailer{m, driver}
+}
+
+func (m tracedMailer) Send(ctx context.Context, msg utils.Message) error {
+	ctx, span := Tracer().Start(ctx, "mail.send",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("mail.driver", m.driver),
+			attribute.Int("mail.attachments", len(msg.Attachments)),
+		),
+	)
+	defer span.End()
+
+	err := m.Mailer.Send(ctx, msg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
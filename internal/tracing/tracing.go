@@ -0,0 +1,96 @@
+// Package tracing sets up OpenTelemetry tracing: the exporter selected by
+// the configuration, W3C trace context propagation, and spans for GORM
+// queries and outgoing mail. HTTP server spans come from
+// middleware.TracingMiddleware.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// instrumentationName names the tracer of every span created here.
+const instrumentationName = "gin-backend-app"
+
+// Config selects where spans are exported. Sampling follows the standard
+// OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG variables and extra
+// resource attributes OTEL_RESOURCE_ATTRIBUTES.
+type Config struct {
+	// Exporter is "none" (default), "stdout" to print spans for local
+	// development, or "otlp" to send them to a collector over OTLP/HTTP.
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER"`
+	// OTLPEndpoint is the traces URL of the collector, e.g.
+	// http://otel-collector:4318/v1/traces. When empty the standard
+	// OTEL_EXPORTER_OTLP_* variables apply.
+	OTLPEndpoint string `yaml:"otlp_endpoint" env:"TRACING_OTLP_ENDPOINT"`
+	// ServiceName is the service.name resource attribute.
+	ServiceName string `yaml:"service_name" env:"TRACING_SERVICE_NAME"`
+}
+
+// Tracer returns the tracer used for the spans of the application.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Setup installs the global tracer provider and propagator for cfg. The
+// returned function flushes buffered spans and must be called on shutdown.
+// With the "none" exporter nothing is recorded, but incoming trace context
+// is still propagated.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	case ExporterOTLP:
+		var opts []otlptracehttp.Option
+		if cfg.OTLPEndpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(cfg.ServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,124 @@
+package validation
+
+import (
+	"errors"
+	"gin-backend-app/internal/apperror"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	id_translations "github.com/go-playground/validator/v10/translations/id"
+)
+
+const defaultLocale = "en"
+
+// universal holds the English and Indonesian translators; English is the
+// fallback for any other Accept-Language.
+var universal = ut.New(en.New(), en.New(), id.New())
+
+// messages are the rules the default translations lack. "invalid" is used
+// for any rule without a message of its own.
+var messages = map[string]map[string]string{
+	"en": {
+		RuleCurrency: "{0} must be a valid ISO 4217 currency code",
+		RuleHexColor: "{0} must be a hex color such as #1E88E5",
+		"invalid":    "{0} is invalid",
+	},
+	"id": {
+		RuleCurrency: "{0} harus berupa kode mata uang ISO 4217 yang valid",
+		RuleHexColor: "{0} harus berupa warna hex seperti #1E88E5",
+		"datetime":   "{0} tidak sesuai dengan format {1}",
+		"invalid":    "{0} tidak valid",
+	},
+}
+
+func registerTranslations(v *validator.Validate) error {
+	defaults := map[string]func(*validator.Validate, ut.Translator) error{
+		"en": en_translations.RegisterDefaultTranslations,
+		"id": id_translations.RegisterDefaultTranslations,
+	}
+	for locale, register := range defaults {
+		trans, _ := universal.GetTranslator(locale)
+		if err := register(v, trans); err != nil {
+			return err
+		}
+		for rule, message := range messages[locale] {
+			if err := trans.Add(rule, message, true); err != nil {
+				return err
+			}
+			if rule == "invalid" {
+				continue
+			}
+			if err := v.RegisterTranslation(rule, trans, noop, translateParam); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// noop is the registration half of RegisterTranslation; the messages are
+// added to the translators directly.
+func noop(ut.Translator) error { return nil }
+
+func translateParam(trans ut.Translator, fe validator.FieldError) string {
+	message, err := trans.T(fe.Tag(), fe.Field(), fe.Param())
+	if err != nil {
+		return fe.Error()
+	}
+	return message
+}
+
+// translator picks the translator of the first supported language in the
+// Accept-Language header.
+func translator(c *gin.Context) ut.Translator {
+	for _, part := range strings.Split(c.GetHeader("Accept-Language"), ",") {
+		tag, _, _ := strings.Cut(part, ";")
+		lang, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+		if trans, found := universal.GetTranslator(strings.ToLower(lang)); found {
+			return trans
+		}
+	}
+	trans, _ := universal.GetTranslator(defaultLocale)
+	return trans
+}
+
+// BindError converts an error of ShouldBindJSON or ShouldBindQuery into the
+// error the handler reports with c.Error. Rule violations become
+// VALIDATION_FAILED with one detail per field, translated to the request's
+// Accept-Language; malformed bodies become INVALID_REQUEST.
+func BindError(c *gin.Context, err error) *apperror.Error {
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		return apperror.Wrap(apperror.CodeInvalidRequest, "Invalid Request Data", err)
+	}
+
+	trans := translator(c)
+	details := make([]apperror.FieldError, 0, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		message := fe.Translate(trans)
+		if message == fe.Error() {
+			message, _ = trans.T("invalid", fe.Field())
+		}
+		details = append(details, apperror.FieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Message: message,
+		})
+	}
+	return apperror.Validation("Invalid Request Data", details...)
+}
+
+// fieldPath drops the struct name from the namespace, leaving the path the
+// client sent, e.g. "splits[0].amount".
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+	return path
+}
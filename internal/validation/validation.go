@@ -0,0 +1,62 @@
+// Package validation configures gin's request validator: JSON field names in
+// errors, the custom rules below, and English and Indonesian messages.
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Custom rules, usable in binding tags next to the built-in ones.
+const (
+	// RuleCurrency accepts an ISO 4217 currency code such as "IDR".
+	RuleCurrency = "currency"
+	// RuleHexColor accepts a "#RRGGBB" color, the format stored in the
+	// color columns of categories and tags.
+	RuleHexColor = "hex_color"
+)
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// Setup registers the field name function, custom rules and translations on
+// gin's validator. It must run before the router serves requests.
+func Setup() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("gin validator is not go-playground/validator")
+	}
+
+	v.RegisterTagNameFunc(fieldName)
+
+	v.RegisterAlias(RuleCurrency, "iso4217")
+	if err := v.RegisterValidation(RuleHexColor, isHexColor); err != nil {
+		return err
+	}
+
+	return registerTranslations(v)
+}
+
+// fieldName names fields after their JSON key, or form key for query
+// parameters, so errors point at what the client sent. Fields with neither
+// keep the Go name.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func isHexColor(fl validator.FieldLevel) bool {
+	return hexColorPattern.MatchString(fl.Field().String())
+}
@@ -0,0 +1,281 @@
+// Package mailtemplate renders the application's transactional emails from
+// embedded templates. Every email has an HTML and a plain-text variant that
+// share a layout, and all copy comes from per-locale message catalogs.
+package mailtemplate
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/*.html templates/*.txt locales/*.json
+var files embed.FS
+
+const (
+	LocaleEN = "en"
+	LocaleID = "id"
+
+	DefaultLocale = LocaleEN
+	appName       = "Traspac"
+)
+
+// Theme is the header colour scheme and icon of an email.
+type Theme struct {
+	From string
+	To   string
+	Icon string
+}
+
+var themes = map[string]Theme{
+	"verification":   {From: "#667eea", To: "#764ba2", Icon: "✉️"},
+	"password_reset": {From: "#ff6b6b", To: "#ee5a52", Icon: "🔐"},
+	"budget_alert":   {From: "#f7971e", To: "#ffd200", Icon: "⚠️"},
+	"digest":         {From: "#11998e", To: "#38ef7d", Icon: "📊"},
+}
+
+var defaultTheme = Theme{From: "#667eea", To: "#764ba2", Icon: "🔔"}
+
+// previews holds sample data for each template, used by the dev preview
+// endpoint.
+var previews = map[string]map[string]any{
+	"verification": {
+		"Name":           "John",
+		"OTPCode":        "ABCD12",
+		"ExpiresInHours": 24,
+	},
+	"password_reset": {
+		"Name":           "John",
+		"OTPCode":        "ABCD12",
+		"ExpiresInHours": 24,
+	},
+	"budget_alert": {
+		"Name":        "John",
+		"Category":    "Food",
+		"Threshold":   80,
+		"PercentUsed": "83",
+		"Spent":       "1660000.00",
+		"Amount":      "2000000.00",
+		"PeriodStart": "2026-10-01",
+		"PeriodEnd":   "2026-10-31",
+	},
+	"digest": {
+		"Name":        "John",
+		"PeriodType":  "monthly",
+		"PeriodStart": "2026-09-01",
+		"PeriodEnd":   "2026-09-30",
+		"Income":      "8000000.00",
+		"Expense":     "5500000.00",
+		"NetFlow":     "2500000.00",
+		"Comparison":  "You spent 12% more than in the previous period.",
+		"TopCategories": []map[string]any{
+			{"Name": "Groceries", "Total": "1800000.00", "Share": "33"},
+			{"Name": "Transport", "Total": "950000.00", "Share": "17"},
+		},
+		"Budgets": []map[string]any{
+			{"Category": "Groceries", "Spent": "1800000.00", "Amount": "2000000.00", "Percent": "90", "Exceeded": false},
+			{"Category": "Dining", "Spent": "1200000.00", "Amount": "1000000.00", "Percent": "120", "Exceeded": true},
+		},
+	},
+}
+
+// Email is a rendered message ready to be queued.
+type Email struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+type pageData struct {
+	Locale  string
+	AppName string
+	Year    int
+	Theme   Theme
+	Data    map[string]any
+}
+
+// ErrUnknownTemplate is returned for a template name that does not exist.
+var ErrUnknownTemplate = errors.New("unknown email template")
+
+var (
+	htmlTemplates = map[string]*htmltemplate.Template{}
+	textTemplates = map[string]*texttemplate.Template{}
+	catalogs      = map[string]map[string]string{}
+)
+
+// placeholderFuncs lets the templates parse; the real "t" is bound to the
+// requested locale on a clone at render time.
+var placeholderFuncs = map[string]any{
+	"t": func(key string, args ...any) string { return key },
+}
+
+func init() {
+	if err := load(); err != nil {
+		panic(fmt.Sprintf("mailtemplate: %v", err))
+	}
+}
+
+func load() error {
+	localeFiles, err := fs.Glob(files, "locales/*.json")
+	if err != nil {
+		return err
+	}
+	for _, file := range localeFiles {
+		raw, err := files.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		catalog := map[string]string{}
+		if err := json.Unmarshal(raw, &catalog); err != nil {
+			return fmt.Errorf("invalid catalog %s: %w", file, err)
+		}
+		catalogs[strings.TrimSuffix(path.Base(file), ".json")] = catalog
+	}
+	if _, ok := catalogs[DefaultLocale]; !ok {
+		return fmt.Errorf("missing catalog for default locale %q", DefaultLocale)
+	}
+
+	pages, err := fs.Glob(files, "templates/*.html")
+	if err != nil {
+		return err
+	}
+	for _, page := range pages {
+		name := strings.TrimSuffix(path.Base(page), ".html")
+		if name == "layout" {
+			continue
+		}
+
+		htmlTmpl, err := htmltemplate.New(name).Funcs(placeholderFuncs).
+			ParseFS(files, "templates/layout.html", page)
+		if err != nil {
+			return err
+		}
+		textTmpl, err := texttemplate.New(name).Funcs(placeholderFuncs).
+			ParseFS(files, "templates/layout.txt", "templates/"+name+".txt")
+		if err != nil {
+			return fmt.Errorf("template %s has no plain-text variant: %w", name, err)
+		}
+
+		htmlTemplates[name] = htmlTmpl
+		textTemplates[name] = textTmpl
+	}
+	return nil
+}
+
+// Names returns the available template names in alphabetical order.
+func Names() []string {
+	names := make([]string, 0, len(htmlTemplates))
+	for name := range htmlTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Locales returns the locales that have a message catalog.
+func Locales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// NormalizeLocale maps a user or Accept-Language style locale ("id-ID",
+// "EN") to a supported catalog, falling back to DefaultLocale.
+func NormalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		locale = locale[:i]
+	}
+	if _, ok := catalogs[locale]; ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// Translate looks key up in the locale's catalog, falling back to the default
+// locale and finally to the key itself, and formats args into it.
+func Translate(locale, key string, args ...any) string {
+	message, ok := catalogs[NormalizeLocale(locale)][key]
+	if !ok {
+		message, ok = catalogs[DefaultLocale][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Render renders the named template in the given locale. The subject is the
+// catalog entry "<name>.subject".
+func Render(name, locale string, data map[string]any) (*Email, error) {
+	htmlTmpl, ok := htmlTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTemplate, name)
+	}
+	textTmpl := textTemplates[name]
+
+	locale = NormalizeLocale(locale)
+	translate := func(key string, args ...any) string {
+		return Translate(locale, key, args...)
+	}
+
+	theme, ok := themes[name]
+	if !ok {
+		theme = defaultTheme
+	}
+	page := pageData{
+		Locale:  locale,
+		AppName: appName,
+		Year:    time.Now().Year(),
+		Theme:   theme,
+		Data:    data,
+	}
+
+	htmlClone, err := htmlTmpl.Clone()
+	if err != nil {
+		return nil, err
+	}
+	var htmlBuf bytes.Buffer
+	if err := htmlClone.Funcs(map[string]any{"t": translate}).ExecuteTemplate(&htmlBuf, "layout", page); err != nil {
+		return nil, fmt.Errorf("failed to render %s html: %w", name, err)
+	}
+
+	textClone, err := textTmpl.Clone()
+	if err != nil {
+		return nil, err
+	}
+	var textBuf bytes.Buffer
+	if err := textClone.Funcs(map[string]any{"t": translate}).ExecuteTemplate(&textBuf, "layout", page); err != nil {
+		return nil, fmt.Errorf("failed to render %s text: %w", name, err)
+	}
+
+	return &Email{
+		Subject: Translate(locale, name+".subject"),
+		HTML:    htmlBuf.String(),
+		Text:    strings.TrimSpace(textBuf.String()) + "\n",
+	}, nil
+}
+
+// Preview renders the named template with its built-in sample data.
+func Preview(name, locale string) (*Email, error) {
+	data, ok := previews[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: no preview data for %s", ErrUnknownTemplate, name)
+	}
+	return Render(name, locale, data)
+}
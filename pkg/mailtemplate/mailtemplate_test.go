@@ -0,0 +1,94 @@
+package mailtemplate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	tests := map[string]string{
+		"id":      LocaleID,
+		"id-ID":   LocaleID,
+		" ID_id ": LocaleID,
+		"EN":      LocaleEN,
+		"en-GB":   LocaleEN,
+		"fr":      DefaultLocale,
+		"":        DefaultLocale,
+	}
+	for in, want := range tests {
+		if got := NormalizeLocale(in); got != want {
+			t.Errorf("NormalizeLocale(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTranslateFallsBackToDefaultLocale(t *testing.T) {
+	const key = "verification.title"
+	translated := catalogs[LocaleID][key]
+	delete(catalogs[LocaleID], key)
+	t.Cleanup(func() { catalogs[LocaleID][key] = translated })
+
+	if got, want := Translate(LocaleID, key), catalogs[DefaultLocale][key]; got != want {
+		t.Errorf("Translate(id, %s) = %q, want the default locale's %q", key, got, want)
+	}
+	if got, want := Translate("fr", "common.otp_expiry", 24), Translate(LocaleEN, "common.otp_expiry", 24); got != want {
+		t.Errorf("Translate(fr) = %q, want %q", got, want)
+	}
+	if got := Translate(LocaleID, "no.such.key"); got != "no.such.key" {
+		t.Errorf("Translate of a missing key = %q, want the key", got)
+	}
+}
+
+func TestCatalogsCoverDefaultLocale(t *testing.T) {
+	for _, locale := range Locales() {
+		for key := range catalogs[DefaultLocale] {
+			if _, ok := catalogs[locale][key]; !ok {
+				t.Errorf("catalog %s is missing %s", locale, key)
+			}
+		}
+	}
+}
+
+func TestRenderLocales(t *testing.T) {
+	for _, name := range Names() {
+		en, err := Preview(name, LocaleEN)
+		if err != nil {
+			t.Fatalf("Preview(%s, en): %v", name, err)
+		}
+		id, err := Preview(name, "id-ID")
+		if err != nil {
+			t.Fatalf("Preview(%s, id-ID): %v", name, err)
+		}
+		fallback, err := Preview(name, "fr")
+		if err != nil {
+			t.Fatalf("Preview(%s, fr): %v", name, err)
+		}
+
+		if id.Subject != catalogs[LocaleID][name+".subject"] || id.Subject == en.Subject {
+			t.Errorf("%s: id subject = %q, want the Indonesian catalog entry", name, id.Subject)
+		}
+		if !strings.Contains(id.HTML, `<html lang="id">`) {
+			t.Errorf("%s: id HTML is not marked lang=id", name)
+		}
+		if fallback.Subject != en.Subject || fallback.Text != en.Text {
+			t.Errorf("%s: unsupported locale did not render in %s", name, DefaultLocale)
+		}
+		for key := range catalogs[DefaultLocale] {
+			for _, email := range []*Email{en, id} {
+				if strings.Contains(email.Text, key) || strings.Contains(email.HTML, key) {
+					t.Errorf("%s: rendered email contains the untranslated key %s", name, key)
+				}
+			}
+		}
+	}
+}
+
+func TestRenderUnknownTemplate(t *testing.T) {
+	if _, err := Render("no_such_template", LocaleEN, nil); !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("Render: error = %v, want ErrUnknownTemplate", err)
+	}
+	if _, err := Preview("no_such_template", LocaleEN); !errors.Is(err, ErrUnknownTemplate) {
+		t.Errorf("Preview: error = %v, want ErrUnknownTemplate", err)
+	}
+}
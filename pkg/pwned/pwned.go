@@ -0,0 +1,71 @@
+// Package pwned looks passwords up in a local copy of the Pwned Passwords
+// SHA-1 dataset, so no password or hash prefix leaves the server.
+//
+// The dataset uses the k-anonymity range layout of the Pwned Passwords API:
+// a directory with one file per 5 character hash prefix, named after the
+// prefix in upper-case hex ("00000.txt" to "FFFFF.txt"). Each file holds the
+// range response for its prefix, one "SUFFIX:COUNT" line per breached
+// password where SUFFIX is the remaining 35 hex characters of the hash. This
+// is what haveibeenpwned-downloader writes when run without single file mode.
+// A lookup reads only the file of the password's prefix.
+package pwned
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PrefixLen is the length of the hash prefix that names a range file.
+const PrefixLen = 5
+
+// firstRange is the range file every complete dataset has.
+const firstRange = "00000"
+
+// Dataset is a Pwned Passwords range directory. It is safe for concurrent
+// use.
+type Dataset struct {
+	dir string
+}
+
+// Open checks that dir is a range directory by looking for its first range
+// file.
+func Open(dir string) (*Dataset, error) {
+	d := &Dataset{dir: dir}
+	if _, err := os.Stat(d.rangePath(firstRange)); err != nil {
+		return nil, fmt.Errorf("%s is not a pwned passwords range directory: %w", dir, err)
+	}
+	return d, nil
+}
+
+// Contains reports whether password appears in the dataset. A missing range
+// file is an error, since the dataset is then incomplete.
+func (d *Dataset) Contains(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:PrefixLen], []byte(hash[PrefixLen:])
+
+	file, err := os.Open(d.rangePath(prefix))
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := bytes.Cut(scanner.Bytes(), []byte(":"))
+		if bytes.EqualFold(bytes.TrimSpace(line), suffix) {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
+
+func (d *Dataset) rangePath(prefix string) string {
+	return filepath.Join(d.dir, prefix+".txt")
+}
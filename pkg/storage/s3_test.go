@@ -0,0 +1,173 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+// The S3 tests run against TEST_S3_ENDPOINT when set, otherwise against a
+// MinIO container started with docker for the duration of the test binary.
+// They are skipped when neither is available.
+
+const minioImage = "quay.io/minio/minio"
+
+var errNoMinIO = errors.New("set TEST_S3_ENDPOINT or install docker to run the S3 tests")
+
+var (
+	minioConfig S3Config
+	minioErr    error
+)
+
+func TestMain(m *testing.M) {
+	stop, err := startMinIO()
+	if err != nil && !errors.Is(err, errNoMinIO) {
+		log.Printf("minio: %v", err)
+	}
+	minioErr = err
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+func TestS3Storage(t *testing.T) {
+	testStorage(t, newS3Storage(t))
+}
+
+// newS3Storage returns storage backed by a fresh bucket that is emptied and
+// removed when t finishes.
+func newS3Storage(t *testing.T) *S3Storage {
+	t.Helper()
+	if minioErr != nil {
+		t.Skipf("minio: %v", minioErr)
+	}
+
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	cfg := minioConfig
+	cfg.Bucket = "test-" + hex.EncodeToString(b)
+
+	s, err := NewS3Storage(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := s.client.MakeBucket(ctx, cfg.Bucket, minio.MakeBucketOptions{Region: cfg.Region}); err != nil {
+		t.Fatalf("create bucket: %v", err)
+	}
+	t.Cleanup(func() {
+		for object := range s.client.ListObjects(ctx, cfg.Bucket, minio.ListObjectsOptions{Recursive: true}) {
+			if object.Err == nil {
+				s.client.RemoveObject(ctx, cfg.Bucket, object.Key, minio.RemoveObjectOptions{})
+			}
+		}
+		if err := s.client.RemoveBucket(ctx, cfg.Bucket); err != nil {
+			t.Errorf("remove bucket: %v", err)
+		}
+	})
+	return s
+}
+
+// startMinIO fills in minioConfig and returns a func that stops the
+// container it started, if any.
+func startMinIO() (func(), error) {
+	noop := func() {}
+
+	if endpoint := os.Getenv("TEST_S3_ENDPOINT"); endpoint != "" {
+		minioConfig = S3Config{
+			Endpoint:  endpoint,
+			AccessKey: envOr("TEST_S3_ACCESS_KEY", "minioadmin"),
+			SecretKey: envOr("TEST_S3_SECRET_KEY", "minioadmin"),
+			Region:    "us-east-1",
+			UseSSL:    os.Getenv("TEST_S3_USE_SSL") == "true",
+		}
+		return noop, nil
+	}
+
+	docker, err := exec.LookPath("docker")
+	if err != nil {
+		return noop, errNoMinIO
+	}
+	port, err := freePort()
+	if err != nil {
+		return noop, err
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(docker, "run", "-d", "--rm",
+		"-p", fmt.Sprintf("127.0.0.1:%d:9000", port),
+		"-e", "MINIO_ROOT_USER=minioadmin", "-e", "MINIO_ROOT_PASSWORD=minioadmin",
+		minioImage, "server", "/data")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		// Most often the daemon is not running; treat it like no docker.
+		return noop, fmt.Errorf("%w (docker run: %s)", errNoMinIO, strings.TrimSpace(stderr.String()))
+	}
+	container := strings.TrimSpace(string(out))
+	stop := func() {
+		if err := exec.Command(docker, "stop", container).Run(); err != nil {
+			log.Printf("minio: stop container %s: %v", container, err)
+		}
+	}
+
+	minioConfig = S3Config{
+		Endpoint:  fmt.Sprintf("127.0.0.1:%d", port),
+		AccessKey: "minioadmin",
+		SecretKey: "minioadmin",
+		Region:    "us-east-1",
+	}
+	if err := waitReady("http://"+minioConfig.Endpoint+"/minio/health/live", 30*time.Second); err != nil {
+		stop()
+		return noop, err
+	}
+	return stop, nil
+}
+
+func waitReady(url string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		resp, err := http.Get(url)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("minio at %s not ready after %s", url, timeout)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
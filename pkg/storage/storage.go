@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+var ErrObjectNotFound = errors.New("object not found")
+
+// Storage stores binary objects such as transaction attachments under
+// slash-separated keys. Calls are abandoned when ctx is cancelled.
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Config selects and configures the storage backend.
+type Config struct {
+	// Driver is "local" or "s3" for any S3-compatible service such as MinIO.
+	Driver   string   `yaml:"driver" env:"STORAGE_DRIVER"`
+	LocalDir string   `yaml:"local_dir" env:"STORAGE_LOCAL_DIR"`
+	S3       S3Config `yaml:"s3"`
+}
+
+// NewStorage builds the backend selected by cfg.Driver.
+func NewStorage(cfg Config) (Storage, error) {
+	slog.Info("storage configured", "driver", cfg.Driver)
+
+	switch cfg.Driver {
+	case "local":
+		return NewLocalStorage(cfg.LocalDir)
+	case "s3":
+		return NewS3Storage(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unsupported storage driver: %s", cfg.Driver)
+	}
+}
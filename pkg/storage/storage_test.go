@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+// testStorage exercises the Storage contract every backend must meet.
+func testStorage(t *testing.T, s Storage) {
+	t.Helper()
+	ctx := context.Background()
+	key := "receipts/2026/receipt.png"
+	data := []byte("\x89PNG\r\n\x1a\nnot really a png")
+
+	if err := s.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "image/png"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	r, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		t.Fatalf("read object: %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("Get = %q, want %q", got, data)
+	}
+
+	if _, err := s.Get(ctx, "receipts/missing.png"); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Get missing: error = %v, want ErrObjectNotFound", err)
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Get after Delete: error = %v, want ErrObjectNotFound", err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Errorf("Delete missing: %v", err)
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	s, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStorage(t, s)
+}
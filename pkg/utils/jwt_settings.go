@@ -5,9 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"gin-backend-app/internal/models"
-	"os"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -28,28 +26,18 @@ type TokenPair struct {
     ExpiresIn    int64  `json:"expires_in"`
 }
 
-var (
-    jwtSecretBytes []byte
-    jwtOnce        sync.Once
-)
+var jwtSecretBytes []byte
 
-func initJWTSecret() {
-    jwtOnce.Do(func() {
-        secret := os.Getenv("JWT_SECRET")
-        if secret == "" {
-            // Development fallback - CHANGE IN PRODUCTION
-            secret = "your_super_secret_jwt_key_change_in_production_min_32_characters"
-        }
-        jwtSecretBytes = []byte(secret)
-    })
+// SetJWTSecret sets the key used to sign and verify tokens. It must be called
+// once at startup, before any token is issued or validated.
+func SetJWTSecret(secret string) {
+    jwtSecretBytes = []byte(secret)
 }
 
 // Generate access token for user
 func GenerateAccessToken(user *models.User) (string, error) {
-    initJWTSecret()
-
     if len(jwtSecretBytes) == 0 {
-        return "", errors.New("JWT_SECRET not set")
+        return "", errors.New("JWT secret not configured")
     }
 
     expireTime := time.Now().Add(24 * time.Hour) // 24 hours for access token
@@ -116,9 +104,8 @@ func CreateToken(user *models.User) (string, error) {
 
 // Validate JWT token
 func ValidateToken(tokenString string) (*Claims, error) {
-    initJWTSecret()
     if len(jwtSecretBytes) == 0 {
-        return nil, errors.New("JWT_SECRET not set")
+        return nil, errors.New("JWT secret not configured")
     }
 
     token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
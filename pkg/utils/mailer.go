@@ -1,17 +1,89 @@
 package utils
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
+	"net/mail"
 	"net/smtp"
-	"os"
+	"time"
 )
 
+// Message is an email with an HTML body, its plain-text alternative and
+// optional attachments.
+type Message struct {
+	To          string
+	Subject     string
+	HTML        string
+	Text        string
+	Attachments []Attachment
+}
+
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Mailer delivers a message. Send gives up when ctx is cancelled or its
+// deadline passes.
 type Mailer interface {
-	Send(to, subject, body string) error
+	Send(ctx context.Context, msg Message) error
+}
+
+// MailerConfig selects and configures the mail backend.
+type MailerConfig struct {
+	// Driver is "smtp" (default), "http", "file" or "log".
+	Driver       string        `yaml:"driver" env:"MAIL_DRIVER"`
+	FromName     string        `yaml:"from_name" env:"SMTP_FROM_NAME"`
+	FromAddress  string        `yaml:"from_address" env:"MAIL_FROM_ADDRESS"`
+	HTTPEndpoint string        `yaml:"http_endpoint" env:"MAIL_HTTP_ENDPOINT"`
+	HTTPAPIKey   string        `yaml:"http_api_key" env:"MAIL_HTTP_API_KEY" secret:"true"`
+	HTTPTimeout  time.Duration `yaml:"http_timeout" env:"MAIL_HTTP_TIMEOUT"`
+	FileDir      string        `yaml:"file_dir" env:"MAIL_FILE_DIR"`
+	SMTP         SMTPConfig    `yaml:"smtp"`
 }
 
+type SMTPConfig struct {
+	Host     string        `yaml:"host" env:"SMTP_HOST"`
+	Port     string        `yaml:"port" env:"SMTP_PORT"`
+	User     string        `yaml:"user" env:"SMTP_USER"`
+	Password string        `yaml:"password" env:"SMTP_PASSWORD" secret:"true"`
+	Security string        `yaml:"security" env:"SMTP_SECURITY"`
+	Timeout  time.Duration `yaml:"timeout" env:"SMTP_TIMEOUT"`
+}
+
+// NewMailer builds the backend selected by cfg.Driver.
+func NewMailer(cfg MailerConfig) (Mailer, error) {
+	slog.Info("mailer configured", "driver", cfg.Driver)
+
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg.SMTP, cfg.FromName, cfg.FromAddress), nil
+	case "http":
+		return NewHTTPMailer(cfg.HTTPEndpoint, cfg.HTTPAPIKey, cfg.FromName, cfg.FromAddress, cfg.HTTPTimeout), nil
+	case "file":
+		return NewFileMailer(cfg.FileDir, cfg.FromName, cfg.FromAddress)
+	case "log":
+		return NewLogMailer(), nil
+	default:
+		return nil, fmt.Errorf("unsupported mail driver: %s", cfg.Driver)
+	}
+}
+
+// SMTP security modes:
+//   - "starttls": plain connection upgraded with STARTTLS (port 587); "tls" is
+//     accepted as an alias for older configs.
+//   - "ssl": implicit TLS from the first byte (port 465).
+//   - "none": no encryption, for local test servers such as MailHog.
+const (
+	SMTPSecurityStartTLS = "starttls"
+	SMTPSecuritySSL      = "ssl"
+	SMTPSecurityNone     = "none"
+)
+
 type SMTPMailer struct {
 	host     string
 	port     string
@@ -19,189 +91,155 @@ type SMTPMailer struct {
 	password string
 	fromName string
 	fromAddr string
-	security string 
+	security string
+	timeout  time.Duration
 }
 
-func NewSMTPMailerFromEnv() *SMTPMailer {
-	security := os.Getenv("SMTP_SECURITY")
-	if security == "" {
-		security = "tls"
+func NewSMTPMailer(cfg SMTPConfig, fromName, fromAddr string) *SMTPMailer {
+	security := cfg.Security
+	if security == "tls" {
+		security = SMTPSecurityStartTLS
 	}
 
 	mailer := &SMTPMailer{
-		host:     os.Getenv("SMTP_HOST"),
-		port:     os.Getenv("SMTP_PORT"),
-		username: os.Getenv("SMTP_USER"),
-		password: os.Getenv("SMTP_PASSWORD"),
-		fromName: os.Getenv("SMTP_FROM_NAME"),
-		fromAddr: os.Getenv("SMTP_USER"),
+		host:     cfg.Host,
+		port:     cfg.Port,
+		username: cfg.User,
+		password: cfg.Password,
+		fromName: fromName,
+		fromAddr: fromAddr,
 		security: security,
+		timeout:  cfg.Timeout,
 	}
 
-	// Debug log to check configuration
-	log.Printf("SMTP Config - Host: %s, Port: %s, User: %s, Security: %s", 
-		mailer.host, mailer.port, mailer.username, mailer.security)
+	slog.Info("smtp mailer configured", "host", mailer.host, "port", mailer.port,
+		"user", mailer.username, "security", mailer.security, "timeout", mailer.timeout.String())
 
 	return mailer
 }
 
-func (m *SMTPMailer) buildMessage(toEmail, subject, body string) []byte {
-	return []byte(fmt.Sprintf(
-		"From: %s <%s>\r\n"+
-			"To: %s\r\n"+
-			"Subject: %s\r\n"+
-			"MIME-Version: 1.0\r\n"+
-			"Content-Type: text/html; charset=\"utf-8\"\r\n"+
-			"\r\n"+
-			"%s\r\n",
-		m.fromName, m.fromAddr, toEmail, subject, body,
-	))
-}
-
-func (m *SMTPMailer) Send(toEmail, subject, body string) error {
+func (m *SMTPMailer) Send(ctx context.Context, message Message) error {
 	// Validate configuration
-	if m.host == "" || m.port == "" || m.username == "" || m.password == "" {
+	if m.host == "" || m.port == "" || m.fromAddr == "" {
 		return fmt.Errorf("SMTP configuration is incomplete")
 	}
+	if m.security != SMTPSecurityNone && (m.username == "" || m.password == "") {
+		return fmt.Errorf("SMTP credentials are required with security %q", m.security)
+	}
 
-	addr := fmt.Sprintf("%s:%s", m.host, m.port)
-	msg := m.buildMessage(toEmail, subject, body)
-
-	log.Printf("Attempting SMTP connection to %s with security: %s", addr, m.security)
-
-	switch m.security {
-	case "ssl":
-		// For port 465 (SSL)
-		tlsConfig := &tls.Config{
-			ServerName: m.host,
-			//dev mode turn it off when it comes to production
-			InsecureSkipVerify: true,
-		}
-
-		conn, err := tls.Dial("tcp", addr, tlsConfig)
-		if err != nil {
-			return fmt.Errorf("failed to dial SSL SMTP: %w", err)
-		}
-		defer conn.Close()
-
-		client, err := smtp.NewClient(conn, m.host)
-		if err != nil {
-			return fmt.Errorf("failed to create SMTP client: %w", err)
-		}
-		defer client.Quit()
-
-		auth := smtp.PlainAuth("", m.username, m.password, m.host)
-		if ok, _ := client.Extension("AUTH"); ok {
-			if err := client.Auth(auth); err != nil {
-				return fmt.Errorf("failed to auth SMTP: %w", err)
-			}
-		}
-
-		if err := client.Mail(m.fromAddr); err != nil {
-			return fmt.Errorf("failed to set MAIL FROM: %w", err)
-		}
-
-		if err := client.Rcpt(toEmail); err != nil {
-			return fmt.Errorf("failed to set RCPT TO: %w", err)
-		}
-
-		w, err := client.Data()
-		if err != nil {
-			return fmt.Errorf("failed to start DATA: %w", err)
-		}
-
-		if _, err := w.Write(msg); err != nil {
-			return fmt.Errorf("failed to write message: %w", err)
-		}
-
-		return w.Close()
-
-	case "tls":
-		// For explicit TLS (usually port 587)
-		tlsConfig := &tls.Config{
-			ServerName: m.host,
-		}
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", message.To, err)
+	}
 
-		conn, err := tls.Dial("tcp", addr, tlsConfig)
-		if err != nil {
-			return fmt.Errorf("failed to dial TLS SMTP: %w", err)
-		}
-		defer conn.Close()
+	msg, err := buildMIMEMessage(m.fromName, m.fromAddr, message)
+	if err != nil {
+		return err
+	}
 
-		client, err := smtp.NewClient(conn, m.host)
-		if err != nil {
-			return fmt.Errorf("failed to create SMTP client: %w", err)
-		}
-		defer client.Quit()
+	client, closeSession, err := m.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeSession()
 
-		auth := smtp.PlainAuth("", m.username, m.password, m.host)
+	if m.username != "" {
 		if ok, _ := client.Extension("AUTH"); ok {
-			if err := client.Auth(auth); err != nil {
+			if err := client.Auth(smtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
 				return fmt.Errorf("failed to auth SMTP: %w", err)
 			}
 		}
+	}
 
-		if err := client.Mail(m.fromAddr); err != nil {
-			return fmt.Errorf("failed to set MAIL FROM: %w", err)
-		}
-
-		if err := client.Rcpt(toEmail); err != nil {
-			return fmt.Errorf("failed to set RCPT TO: %w", err)
-		}
-
-		w, err := client.Data()
-		if err != nil {
-			return fmt.Errorf("failed to start DATA: %w", err)
-		}
+	if err := client.Mail(m.fromAddr); err != nil {
+		return fmt.Errorf("failed to set MAIL FROM: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("failed to set RCPT TO: %w", err)
+	}
 
-		if _, err := w.Write(msg); err != nil {
-			return fmt.Errorf("failed to write message: %w", err)
-		}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start DATA: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to finish DATA: %w", err)
+	}
 
-		return w.Close()
+	return client.Quit()
+}
 
-	case "starttls":
-		// For STARTTLS (usually port 587)
-		c, err := smtp.Dial(addr)
-		if err != nil {
-			return fmt.Errorf("failed to dial SMTP: %w", err)
-		}
-		defer c.Quit()
+// Ping checks that the server accepts a session, upgraded to TLS as
+// configured, without authenticating or sending mail.
+func (m *SMTPMailer) Ping(ctx context.Context) error {
+	if m.host == "" || m.port == "" {
+		return fmt.Errorf("SMTP configuration is incomplete")
+	}
+	client, closeSession, err := m.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeSession()
+	return client.Quit()
+}
 
-		tlsConfig := &tls.Config{
-			ServerName: m.host,
-		}
+// dial opens a session with the server, upgraded to TLS as configured. The
+// returned func closes it.
+func (m *SMTPMailer) dial(ctx context.Context) (*smtp.Client, func(), error) {
+	addr := net.JoinHostPort(m.host, m.port)
+	tlsConfig := &tls.Config{ServerName: m.host, MinVersion: tls.VersionTLS12}
+	dialer := &net.Dialer{Timeout: m.timeout}
 
-		if err := c.StartTLS(tlsConfig); err != nil {
-			return fmt.Errorf("failed to start TLS: %w", err)
-		}
+	var conn net.Conn
+	var err error
+	switch m.security {
+	case SMTPSecuritySSL:
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	case SMTPSecurityStartTLS, SMTPSecurityNone:
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	default:
+		return nil, nil, fmt.Errorf("unsupported SMTP security mode: %s", m.security)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to dial SMTP %s: %w", addr, err)
+	}
 
-		auth := smtp.PlainAuth("", m.username, m.password, m.host)
-		if err := c.Auth(auth); err != nil {
-			return fmt.Errorf("failed to auth SMTP: %w", err)
-		}
+	// One deadline covers the whole session so a stalled server cannot
+	// block the outbox worker. An earlier ctx deadline wins, and cancelling
+	// ctx closes the connection to abort a session in progress.
+	deadline := time.Now().Add(m.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to set SMTP deadline: %w", err)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
 
-		if err := c.Mail(m.fromAddr); err != nil {
-			return fmt.Errorf("failed to set MAIL FROM: %w", err)
-		}
-		if err := c.Rcpt(toEmail); err != nil {
-			return fmt.Errorf("failed to set RCPT TO: %w", err)
-		}
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		stop()
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to create SMTP client: %w", err)
+	}
+	closeSession := func() {
+		stop()
+		client.Close()
+	}
 
-		w, err := c.Data()
-		if err != nil {
-			return fmt.Errorf("failed to start DATA: %w", err)
+	if m.security == SMTPSecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			closeSession()
+			return nil, nil, fmt.Errorf("SMTP server %s does not support STARTTLS", addr)
 		}
-
-		if _, err := w.Write(msg); err != nil {
-			return fmt.Errorf("failed to write message: %w", err)
+		if err := client.StartTLS(tlsConfig); err != nil {
+			closeSession()
+			return nil, nil, fmt.Errorf("failed to start TLS: %w", err)
 		}
-
-		return w.Close()
-
-	default:
-		// Plain SMTP (not recommended for production)
-		auth := smtp.PlainAuth("", m.username, m.password, m.host)
-		return smtp.SendMail(addr, auth, m.fromAddr, []string{toEmail}, msg)
 	}
-}
\ No newline at end of file
+	return client, closeSession, nil
+}
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// LogMailer writes emails to the application log instead of sending them.
+// Meant for local development only: bodies may contain OTP codes.
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	body := msg.Text
+	if body == "" {
+		body = msg.HTML
+	}
+	slog.InfoContext(ctx, "mail", "to", msg.To, "subject", msg.Subject, "body", body)
+	return nil
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// FileMailer stores every email as an .eml file in a directory so it can be
+// opened with a mail client during development.
+type FileMailer struct {
+	dir      string
+	fromName string
+	fromAddr string
+}
+
+func NewFileMailer(dir, fromName, fromAddr string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create mail dir: %w", err)
+	}
+	return &FileMailer{dir: dir, fromName: fromName, fromAddr: fromAddr}, nil
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	name := fmt.Sprintf("%s_%s.eml", time.Now().Format("20060102T150405.000000000"), unsafeFileChars.ReplaceAllString(msg.To, "_"))
+	raw, err := buildMIMEMessage(m.fromName, m.fromAddr, msg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(m.dir, name), raw, 0o640)
+}
@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTPMailer delivers emails through a transactional email HTTP API. The
+// message is posted as JSON with a bearer API key; providers with a
+// different payload can be put behind a small relay.
+type HTTPMailer struct {
+	endpoint string
+	apiKey   string
+	fromName string
+	fromAddr string
+	client   *http.Client
+}
+
+type httpMailAddress struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email"`
+}
+
+type httpMailPayload struct {
+	From    httpMailAddress   `json:"from"`
+	To      []httpMailAddress `json:"to"`
+	Subject string            `json:"subject"`
+	HTML    string            `json:"html"`
+	Text    string            `json:"text,omitempty"`
+	// Attachments carry base64 content, the common shape of mail APIs.
+	Attachments []httpMailAttachment `json:"attachments,omitempty"`
+}
+
+type httpMailAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type,omitempty"`
+	Content     string `json:"content"`
+}
+
+func NewHTTPMailer(endpoint, apiKey, fromName, fromAddr string, timeout time.Duration) *HTTPMailer {
+	return &HTTPMailer{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		fromName: fromName,
+		fromAddr: fromAddr,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (m *HTTPMailer) Send(ctx context.Context, msg Message) error {
+	if m.endpoint == "" || m.fromAddr == "" {
+		return fmt.Errorf("HTTP mailer configuration is incomplete")
+	}
+
+	attachments := make([]httpMailAttachment, 0, len(msg.Attachments))
+	for _, attachment := range msg.Attachments {
+		attachments = append(attachments, httpMailAttachment{
+			Filename:    attachment.Filename,
+			ContentType: attachment.ContentType,
+			Content:     base64.StdEncoding.EncodeToString(attachment.Data),
+		})
+	}
+
+	payload, err := json.Marshal(httpMailPayload{
+		From:        httpMailAddress{Name: m.fromName, Email: m.fromAddr},
+		To:          []httpMailAddress{{Email: msg.To}},
+		Subject:     msg.Subject,
+		HTML:        msg.HTML,
+		Text:        msg.Text,
+		Attachments: attachments,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode email: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build mail request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if m.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+m.apiKey)
+	}
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call mail API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("mail API returned %d: %s", resp.StatusCode, bytes.TrimSpace(snippet))
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// buildMIMEMessage renders msg as an RFC 5322 message. Display names and the
+// subject are RFC 2047 encoded, bodies are quoted-printable and attachments
+// base64. The layout is:
+//
+//	multipart/mixed            (only with attachments)
+//	├── multipart/alternative  (only with a plain-text part)
+//	│   ├── text/plain
+//	│   └── text/html
+//	└── attachments...
+func buildMIMEMessage(fromName, fromAddr string, msg Message) ([]byte, error) {
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient %q: %w", msg.To, err)
+	}
+	from := &mail.Address{Name: fromName, Address: fromAddr}
+
+	var buf bytes.Buffer
+	writeHeader(&buf, "From", from.String())
+	writeHeader(&buf, "To", to.String())
+	writeHeader(&buf, "Subject", mime.QEncoding.Encode("utf-8", sanitizeHeader(msg.Subject)))
+	writeHeader(&buf, "Date", time.Now().Format(time.RFC1123Z))
+	writeHeader(&buf, "Message-ID", newMessageID(fromAddr))
+	writeHeader(&buf, "MIME-Version", "1.0")
+
+	bodyType, body, err := buildBody(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(msg.Attachments) == 0 {
+		writeHeaders(&buf, bodyType)
+		buf.WriteString("\r\n")
+		buf.Write(body)
+		return buf.Bytes(), nil
+	}
+
+	var mixed bytes.Buffer
+	writer := multipart.NewWriter(&mixed)
+	part, err := writer.CreatePart(bodyType)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(body); err != nil {
+		return nil, err
+	}
+	for _, attachment := range msg.Attachments {
+		if err := writeAttachment(writer, attachment); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	writeHeader(&buf, "Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": writer.Boundary()}))
+	buf.WriteString("\r\n")
+	buf.Write(mixed.Bytes())
+	return buf.Bytes(), nil
+}
+
+// buildBody returns the headers and content of the message body: a single
+// HTML part, or multipart/alternative when there is a plain-text part.
+func buildBody(msg Message) (textproto.MIMEHeader, []byte, error) {
+	if msg.Text == "" {
+		return textPart("text/html", msg.HTML)
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, p := range []struct{ contentType, content string }{
+		{"text/plain", msg.Text},
+		{"text/html", msg.HTML},
+	} {
+		header, content, err := textPart(p.contentType, p.content)
+		if err != nil {
+			return nil, nil, err
+		}
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err := part.Write(content); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": writer.Boundary()}))
+	return header, body.Bytes(), nil
+}
+
+func textPart(contentType, content string) (textproto.MIMEHeader, []byte, error) {
+	var encoded bytes.Buffer
+	qp := quotedprintable.NewWriter(&encoded)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return nil, nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", mime.FormatMediaType(contentType, map[string]string{"charset": "utf-8"}))
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+	return header, encoded.Bytes(), nil
+}
+
+func writeAttachment(writer *multipart.Writer, attachment Attachment) error {
+	filename := filepath.Base(sanitizeHeader(attachment.Filename))
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(filename))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Transfer-Encoding", "base64")
+	header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return err
+	}
+
+	// RFC 2045 limits encoded lines to 76 characters.
+	encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+	for len(encoded) > 76 {
+		if _, err := part.Write([]byte(encoded[:76] + "\r\n")); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err = part.Write([]byte(encoded + "\r\n"))
+	return err
+}
+
+func writeHeader(buf *bytes.Buffer, key, value string) {
+	buf.WriteString(key)
+	buf.WriteString(": ")
+	buf.WriteString(value)
+	buf.WriteString("\r\n")
+}
+
+func writeHeaders(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	for key, values := range header {
+		for _, value := range values {
+			writeHeader(buf, key, value)
+		}
+	}
+}
+
+// sanitizeHeader drops CR and LF so user-controlled values cannot inject
+// headers.
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", "", "\n", " ").Replace(value)
+}
+
+func newMessageID(fromAddr string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(fromAddr, "@"); at >= 0 && at < len(fromAddr)-1 {
+		domain = fromAddr[at+1:]
+	}
+
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return fmt.Sprintf("<%d@%s>", time.Now().UnixNano(), domain)
+	}
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().Unix(), hex.EncodeToString(random), domain)
+}
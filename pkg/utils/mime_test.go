@@ -0,0 +1,194 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+func TestBuildMIMEMessageHTMLOnly(t *testing.T) {
+	raw, err := buildMIMEMessage("Traspac", "noreply@traspac.test", Message{
+		To:      "Ana <ana@example.com>",
+		Subject: "Selamat datang",
+		HTML:    "<p>Halo Ana, " + strings.Repeat("x", 2000) + "</p>",
+	})
+	if err != nil {
+		t.Fatalf("buildMIMEMessage: %v", err)
+	}
+	msg := readMessage(t, raw)
+
+	mediaType, _ := parseMediaType(t, msg.Header.Get("Content-Type"))
+	if mediaType != "text/html" {
+		t.Errorf("Content-Type = %s, want text/html", mediaType)
+	}
+	if got := msg.Header.Get("Content-Transfer-Encoding"); got != "quoted-printable" {
+		t.Errorf("Content-Transfer-Encoding = %q, want quoted-printable", got)
+	}
+	if id := msg.Header.Get("Message-ID"); !strings.HasSuffix(id, "@traspac.test>") {
+		t.Errorf("Message-ID = %q, want the sender's domain", id)
+	}
+	assertLineLength(t, raw)
+}
+
+func TestBuildMIMEMessageEncodesHeaders(t *testing.T) {
+	raw, err := buildMIMEMessage("Tim Keuangan – Traspac", "noreply@traspac.test", Message{
+		To:      "Zoë <zoe@example.com>",
+		Subject: "Anggaran 💸 terlampaui\r\nBcc: attacker@example.com",
+		HTML:    "<p>hi</p>",
+	})
+	if err != nil {
+		t.Fatalf("buildMIMEMessage: %v", err)
+	}
+	msg := readMessage(t, raw)
+
+	if got := msg.Header.Get("Bcc"); got != "" {
+		t.Errorf("Bcc = %q, want no injected header", got)
+	}
+	if subject := msg.Header.Get("Subject"); !strings.HasPrefix(subject, "=?utf-8?q?") {
+		t.Errorf("raw Subject = %q, want RFC 2047 Q-encoding", subject)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("decode Subject: %v", err)
+	}
+	if want := "Anggaran 💸 terlampaui Bcc: attacker@example.com"; subject != want {
+		t.Errorf("Subject = %q, want %q", subject, want)
+	}
+
+	from, err := msg.Header.AddressList("From")
+	if err != nil || len(from) != 1 || from[0].Name != "Tim Keuangan – Traspac" || from[0].Address != "noreply@traspac.test" {
+		t.Errorf("From = %v, %v", from, err)
+	}
+	to, err := msg.Header.AddressList("To")
+	if err != nil || len(to) != 1 || to[0].Name != "Zoë" {
+		t.Errorf("To = %v, %v", to, err)
+	}
+}
+
+func TestBuildMIMEMessageMultipart(t *testing.T) {
+	attachment := bytes.Repeat([]byte{0x00, 0xff, 0x10, 0x80}, 100)
+	raw, err := buildMIMEMessage("Traspac", "noreply@traspac.test", Message{
+		To:      "ana@example.com",
+		Subject: "Laporan bulanan",
+		HTML:    "<p>Ringkasan = 100%</p>",
+		Text:    "Ringkasan = 100%",
+		Attachments: []Attachment{
+			{Filename: "../laporan\r\n.csv", Data: attachment},
+		},
+	})
+	if err != nil {
+		t.Fatalf("buildMIMEMessage: %v", err)
+	}
+	msg := readMessage(t, raw)
+	assertLineLength(t, raw)
+
+	mixed := multipartParts(t, msg.Header.Get("Content-Type"), "multipart/mixed", msg.Body)
+	if len(mixed) != 2 {
+		t.Fatalf("multipart/mixed has %d parts, want 2", len(mixed))
+	}
+
+	alternative := multipartParts(t, mixed[0].header.Get("Content-Type"), "multipart/alternative", bytes.NewReader(mixed[0].body))
+	if len(alternative) != 2 {
+		t.Fatalf("multipart/alternative has %d parts, want 2", len(alternative))
+	}
+	for i, want := range []struct{ contentType, body string }{
+		{"text/plain", "Ringkasan = 100%"},
+		{"text/html", "<p>Ringkasan = 100%</p>"},
+	} {
+		mediaType, params := parseMediaType(t, alternative[i].header.Get("Content-Type"))
+		if mediaType != want.contentType || params["charset"] != "utf-8" {
+			t.Errorf("part %d Content-Type = %s %v, want %s utf-8", i, mediaType, params, want.contentType)
+		}
+		// multipart.Reader decodes quoted-printable parts itself.
+		if string(alternative[i].body) != want.body {
+			t.Errorf("part %d body = %q, want %q", i, alternative[i].body, want.body)
+		}
+	}
+
+	file := mixed[1]
+	if got := file.header.Get("Content-Transfer-Encoding"); got != "base64" {
+		t.Errorf("attachment encoding = %q, want base64", got)
+	}
+	if got := file.header.Get("Content-Type"); !strings.HasPrefix(got, "text/csv") {
+		t.Errorf("attachment Content-Type = %q, want text/csv from the extension", got)
+	}
+	_, params := parseMediaType(t, file.header.Get("Content-Disposition"))
+	if params["filename"] != "laporan .csv" {
+		t.Errorf("attachment filename = %q, want the sanitized base name", params["filename"])
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(file.body), "\r\n", ""))
+	if err != nil || !bytes.Equal(decoded, attachment) {
+		t.Errorf("attachment data does not round-trip (error %v)", err)
+	}
+}
+
+func TestBuildMIMEMessageRejectsInvalidRecipient(t *testing.T) {
+	if _, err := buildMIMEMessage("Traspac", "noreply@traspac.test", Message{To: "not an address"}); err == nil {
+		t.Error("buildMIMEMessage accepted an invalid recipient")
+	}
+}
+
+type partData struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+func readMessage(t *testing.T, raw []byte) *mail.Message {
+	t.Helper()
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("parse message: %v\n%s", err, raw)
+	}
+	return msg
+}
+
+func parseMediaType(t *testing.T, value string) (string, map[string]string) {
+	t.Helper()
+	mediaType, params, err := mime.ParseMediaType(value)
+	if err != nil {
+		t.Fatalf("parse media type %q: %v", value, err)
+	}
+	return mediaType, params
+}
+
+// multipartParts checks that contentType is wantType and reads its parts.
+func multipartParts(t *testing.T, contentType, wantType string, body io.Reader) []partData {
+	t.Helper()
+	mediaType, params := parseMediaType(t, contentType)
+	if mediaType != wantType {
+		t.Fatalf("Content-Type = %s, want %s", mediaType, wantType)
+	}
+
+	var parts []partData
+	reader := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatalf("read %s part: %v", wantType, err)
+		}
+		data, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatalf("read %s part: %v", wantType, err)
+		}
+		parts = append(parts, partData{header: part.Header, body: data})
+	}
+}
+
+// assertLineLength checks the RFC 5322 limit of 998 characters per line.
+func assertLineLength(t *testing.T, raw []byte) {
+	t.Helper()
+	for _, line := range strings.Split(string(raw), "\r\n") {
+		if len(line) > 998 {
+			t.Errorf("line of %d characters", len(line))
+		}
+	}
+}
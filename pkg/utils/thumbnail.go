@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// GenerateThumbnail decodes a JPEG, PNG or WebP image and returns a JPEG
+// scaled down to fit within maxDim x maxDim. Smaller images are re-encoded
+// without upscaling.
+func GenerateThumbnail(r io.Reader, maxDim int) ([]byte, error) {
+	src, _, err := image.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil, fmt.Errorf("image has no pixels")
+	}
+
+	if width > maxDim || height > maxDim {
+		if width >= height {
+			height = height * maxDim / width
+			width = maxDim
+		} else {
+			width = width * maxDim / height
+			height = maxDim
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, max(width, 1), max(height, 1)))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -1,18 +1,20 @@
 #!/bin/bash
 
-# This script is used to run database migrations.
+# Runs the embedded database migrations against the database configured in
+# the environment (DATABASE_URL or DB_HOST/DB_PORT/DB_USER/DB_PASSWORD/DB_NAME).
+#
+#   ./scripts/migrate.sh            # apply pending migrations
+#   ./scripts/migrate.sh status
+#   ./scripts/migrate.sh down -steps 1
+#   ./scripts/migrate.sh new add_something
 
 # Exit immediately if a command exits with a non-zero status.
 set -e
 
-# Define the database connection parameters
-DB_USER="your_db_user"
-DB_PASSWORD="your_db_password"
-DB_NAME="your_db_name"
-DB_HOST="localhost"
-DB_PORT="5432"
+cd "$(dirname "$0")/.."
 
-# Run the migration SQL file
-psql -U $DB_USER -d $DB_NAME -h $DB_HOST -p $DB_PORT -f ./internal/database/migrations/001_create_users_table.sql
+if [ $# -eq 0 ]; then
+	set -- up
+fi
 
-echo "Database migration completed successfully."
\ No newline at end of file
+go run ./cmd/server migrate "$@"